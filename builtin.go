@@ -0,0 +1,127 @@
+package notation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Builtins selects groups of built-in renderers. The built-in renderers print the values of certain standard
+// library types in a readable form, instead of their internal structure.
+type Builtins int
+
+const (
+
+	// TimeBuiltins prints time.Time values in RFC3339 format with nanoseconds and the name of the zone,
+	// time.Duration values in the format returned by their String method, e.g. 1h2m3s, and time.Location
+	// values by their name.
+	TimeBuiltins Builtins = 1 << iota
+
+	// BigBuiltins prints the math/big types, big.Int, big.Float and big.Rat, as numbers, e.g.
+	// big.Int(123456789012345678901) or big.Rat(1/3).
+	BigBuiltins
+
+	// NetBuiltins prints net.IP, net.IPNet, netip.Addr and netip.Prefix values in their textual form, e.g.
+	// net.IP(10.0.0.1) or netip.Prefix(10.0.0.0/8).
+	NetBuiltins
+
+	// URLBuiltins prints url.URL values in their textual form, e.g. url.URL(https://example.org/foo).
+	URLBuiltins
+
+	// ErrorBuiltins prints the values implementing the error interface by their message, followed by the
+	// errors that they wrap, based on their Unwrap() error or Unwrap() []error method.
+	ErrorBuiltins
+
+	// SyncBuiltins prints the types of the sync and sync/atomic packages by their logical state, e.g.
+	// sync.Mutex{locked}, sync.WaitGroup{counter: 3} or atomic.Int64(42). The state is read atomically.
+	SyncBuiltins
+)
+
+// AllBuiltins selects all the built-in renderers.
+const AllBuiltins = ^Builtins(0)
+
+// the render function of a builtin can decline rendering a value, in which
+// case the value is printed based on its internal structure.
+type builtin struct {
+	group  Builtins
+	render func(o opts, c *Printer, p *pending, r reflect.Value) (node, bool)
+}
+
+var builtins = make(map[reflect.Type]builtin)
+
+func registerBuiltin(group Builtins, v interface{}, render func(opts, *Printer, *pending, reflect.Value) (node, bool)) {
+	builtins[reflect.TypeOf(v)] = builtin{group: group, render: render}
+}
+
+// builtinTypeName qualifies the type names at least with the package name,
+// to make the well known types recognizable.
+func (pr *Printer) builtinTypeName(t reflect.Type) string {
+	if pr.TypeNames == FullTypeNames {
+		return pr.typeName(t)
+	}
+
+	qualified := *pr
+	qualified.TypeNames = PackageTypeNames
+	return qualified.typeName(t)
+}
+
+// pointerTo returns a pointer to the value, or, when the value is not
+// addressable, a pointer to a copy of it.
+func pointerTo(r reflect.Value) interface{} {
+	if r.CanAddr() {
+		return r.Addr().Interface()
+	}
+
+	p := reflect.New(r.Type())
+	p.Elem().Set(r)
+	return p.Interface()
+}
+
+func reflectBuiltinText(o opts, c *Printer, r reflect.Value, s string) (node, bool) {
+	// the text is represented by str, because it's a value, not syntax:
+	text := nodeOf(str{val: s})
+	if _, t, _ := withType(o); !t {
+		return text, true
+	}
+
+	return nodeOf(c.builtinTypeName(r.Type()), "(", text, ")"), true
+}
+
+func reflectStringer(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	return reflectBuiltinText(o, c, r, pointerTo(r).(fmt.Stringer).String())
+}
+
+func findBuiltin(t reflect.Type) (builtin, bool) {
+	if b, ok := builtins[t]; ok {
+		return b, true
+	}
+
+	if isAtomicPointer(t) {
+		return builtin{group: SyncBuiltins, render: reflectAtomicPointer}, true
+	}
+
+	if t.Kind() != reflect.Interface && t.Implements(errorType) {
+		return builtin{group: ErrorBuiltins, render: reflectError}, true
+	}
+
+	return builtin{}, false
+}
+
+func reflectBuiltin(o opts, c *Printer, p *pending, r reflect.Value) (node, bool) {
+	if !r.IsValid() || !r.CanInterface() {
+		return node{}, false
+	}
+
+	b, ok := findBuiltin(r.Type())
+	if !ok || c.RawBuiltins&b.group != 0 {
+		return node{}, false
+	}
+
+	switch r.Kind() {
+	case reflect.Map, reflect.Ptr, reflect.Slice:
+		if r.IsNil() {
+			return node{}, false
+		}
+	}
+
+	return b.render(o, c, p, r)
+}
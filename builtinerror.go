@@ -0,0 +1,132 @@
+package notation
+
+import "reflect"
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// errorMessage protects against the panics in the Error() methods of the
+// custom error types, e.g. when they are called on a typed nil.
+func errorMessage(err error) (msg string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	return err.Error(), true
+}
+
+func unwrapErrors(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if u := e.Unwrap(); u != nil {
+			return []error{u}
+		}
+	case interface{ Unwrap() []error }:
+		var u []error
+		for _, ui := range e.Unwrap() {
+			if ui != nil {
+				u = append(u, ui)
+			}
+		}
+
+		return u
+	}
+
+	return nil
+}
+
+// maxUnwrapDepth limits the chains of the wrapped errors whose cycles cannot be detected, because they are
+// neither references nor comparable values.
+const maxUnwrapDepth = 64
+
+// checkPendingError detects the cycles in the chains of the wrapped errors that are not pointers, maps or
+// slices, and so they are not tracked by checkPending. The Unwrap method of such errors returns a new copy on
+// every call, and so they are identified by their value, when it's comparable. Otherwise, only the depth of
+// the chain is limited.
+func checkPendingError(p *pending, r reflect.Value) (applyRef func(node) node, ref node, isPending bool) {
+	applyRef = func(n node) node { return n }
+	switch r.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr:
+		return
+	}
+
+	if !r.Comparable() {
+		if p.unwrapDepth >= maxUnwrapDepth {
+			return applyRef, nodeOf("{...}"), true
+		}
+
+		p.unwrapDepth++
+		applyRef = func(n node) node {
+			p.unwrapDepth--
+			return n
+		}
+
+		return
+	}
+
+	key := r.Interface()
+	nr, isPending := p.errors[key]
+	if isPending {
+		nr.refCount++
+		p.errors[key] = nr
+		ref = nodeOf("r", nr.id)
+		return
+	}
+
+	nr = nodeRef{id: p.idCounter}
+	p.idCounter++
+	p.errors[key] = nr
+	applyRef = func(n node) node {
+		nr = p.errors[key]
+		if nr.refCount > 0 {
+			n = labelRef(nr.id, n)
+		}
+
+		delete(p.errors, key)
+		return n
+	}
+
+	return
+}
+
+func reflectError(o opts, c *Printer, p *pending, r reflect.Value) (node, bool) {
+	err := r.Interface().(error)
+	msg, ok := errorMessage(err)
+	if !ok {
+		return node{}, false
+	}
+
+	applyRef, ref, isPending := checkPendingError(p, r)
+	if isPending {
+		return ref, true
+	}
+
+	wr := wrapper{sep: ", ", suffix: ","}
+	wr.items = append(wr.items, nodeOf("error: ", reflectString(none, c, reflect.ValueOf(msg))))
+
+	// the wrapped errors are printed with their dynamic type, the same way as
+	// the values stored in interfaces:
+	//
+	var wrapped []node
+	for _, u := range unwrapErrors(err) {
+		wrapped = append(wrapped, reflectValue(o&^skipTypes, c, p, reflect.ValueOf(u)))
+	}
+
+	switch len(wrapped) {
+	case 0:
+	case 1:
+		wr.items = append(wr.items, nodeOf("wraps: ", wrapped[0]))
+	default:
+		wr.items = append(
+			wr.items,
+			nodeOf("wraps: []{", wrapper{sep: ", ", suffix: ",", items: wrapped}, "}"),
+		)
+	}
+
+	if _, t, _ := withType(o); !t {
+		return applyRef(nodeOf("{", wr, "}")), true
+	}
+
+	return applyRef(nodeOf(reflectType(c, r.Type()), "{", wr, "}")), true
+}
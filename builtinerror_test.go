@@ -0,0 +1,134 @@
+package notation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type cyclicError struct{ next error }
+
+type panickingError struct{ msg *string }
+
+func (e *cyclicError) Error() string   { return "cyclic" }
+func (e *cyclicError) Unwrap() error   { return e.next }
+func (e panickingError) Error() string { return *e.msg }
+
+// the value type errors return a new copy from Unwrap on every call:
+type (
+	valueError struct {
+		msg   string
+		inner *valueError
+	}
+
+	selfError struct{ msg string }
+	listError struct{ msgs []string }
+)
+
+func (e valueError) Error() string { return e.msg }
+func (e valueError) Unwrap() error { return *e.inner }
+func (e selfError) Error() string  { return e.msg }
+func (e selfError) Unwrap() error  { return e }
+func (e listError) Error() string  { return strings.Join(e.msgs, ", ") }
+func (e listError) Unwrap() error  { return e }
+
+func TestErrorBuiltins(t *testing.T) {
+	notFound := errors.New("not found")
+	wrapped := fmt.Errorf("read config: %w", notFound)
+	cyclic := &cyclicError{}
+	cyclic.next = cyclic
+	valueA, valueB := &valueError{msg: "a"}, &valueError{msg: "b"}
+	valueA.inner, valueB.inner = valueB, valueA
+	for _, test := range []struct {
+		title  string
+		sprint func(...interface{}) string
+		value  interface{}
+		expect string
+	}{{
+		title:  "simple",
+		sprint: Sprint,
+		value:  notFound,
+		expect: `{error: "not found"}`,
+	}, {
+		title:  "simple with types",
+		sprint: Sprintt,
+		value:  notFound,
+		expect: `*errorString{error: "not found"}`,
+	}, {
+		title:  "wrapped",
+		sprint: Sprint,
+		value:  wrapped,
+		expect: `{error: "read config: not found", wraps: {error: "not found"}}`,
+	}, {
+		title:  "wrapped with types",
+		sprint: Printer{TypeNames: PackageTypeNames}.Sprintt,
+		value:  wrapped,
+		expect: `*fmt.wrapError{error: "read config: not found", wraps: *errors.errorString{error: "not found"}}`,
+	}, {
+		title:  "joined",
+		sprint: Sprint,
+		value:  errors.Join(notFound, nil, errors.New("timeout")),
+		expect: `{error: "not found\ntimeout", wraps: []{{error: "not found"}, {error: "timeout"}}}`,
+	}, {
+		title:  "in a struct field",
+		sprint: Sprintt,
+		value:  struct{ err error }{wrapped},
+		expect: `struct{err error}{err: *wrapError{error: "read config: not found", wraps: *errorString{error: "not found"}}}`,
+	}, {
+		title:  "nil",
+		sprint: Sprint,
+		value:  struct{ err error }{},
+		expect: `{err: nil}`,
+	}, {
+		title:  "cyclic",
+		sprint: Sprint,
+		value:  cyclic,
+		expect: `r0={error: "cyclic", wraps: r0}`,
+	}, {
+		title:  "cyclic values",
+		sprint: Sprint,
+		value:  *valueA,
+		expect: `r0={error: "a", wraps: {error: "b", wraps: r0}}`,
+	}, {
+		title:  "unwrapping to itself",
+		sprint: Sprint,
+		value:  selfError{msg: "self"},
+		expect: `r0={error: "self", wraps: r0}`,
+	}, {
+		title:  "unwrapping to itself, not comparable",
+		sprint: Sprint,
+		value:  listError{msgs: []string{"list"}},
+		expect: strings.Repeat(`{error: "list", wraps: `, maxUnwrapDepth) + "{...}" + strings.Repeat("}", maxUnwrapDepth),
+	}, {
+		title:  "panicking",
+		sprint: Sprint,
+		value:  panickingError{},
+		expect: `{msg: nil}`,
+	}, {
+		title:  "raw",
+		sprint: Printer{RawBuiltins: ErrorBuiltins}.Sprint,
+		value:  notFound,
+		expect: `{s: "not found"}`,
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.sprint(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+
+	t.Run("wrapped, multiple lines", func(t *testing.T) {
+		const expect = `{
+	error: "read config: not found",
+	wraps: {error: "not found"},
+}`
+
+		defer withEnv(t, "TABWIDTH=8", "LINEWIDTH=36", "LINEWIDTH1=36")()
+		s := Sprintw(wrapped)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
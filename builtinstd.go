@@ -0,0 +1,28 @@
+package notation
+
+import (
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+)
+
+func init() {
+	registerBuiltin(BigBuiltins, big.Int{}, reflectStringer)
+	registerBuiltin(BigBuiltins, big.Float{}, reflectBigFloat)
+	registerBuiltin(BigBuiltins, big.Rat{}, reflectBigRat)
+	registerBuiltin(NetBuiltins, net.IP{}, reflectStringer)
+	registerBuiltin(NetBuiltins, net.IPNet{}, reflectStringer)
+	registerBuiltin(NetBuiltins, netip.Addr{}, reflectStringer)
+	registerBuiltin(NetBuiltins, netip.Prefix{}, reflectStringer)
+	registerBuiltin(URLBuiltins, url.URL{}, reflectStringer)
+}
+
+func reflectBigFloat(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	return reflectBuiltinText(o, c, r, pointerTo(r).(*big.Float).Text('g', -1))
+}
+
+func reflectBigRat(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	return reflectBuiltinText(o, c, r, pointerTo(r).(*big.Rat).RatString())
+}
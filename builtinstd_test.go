@@ -0,0 +1,99 @@
+package notation
+
+import (
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestStdBuiltins(t *testing.T) {
+	i, _ := new(big.Int).SetString("123456789012345678901", 10)
+	u, _ := url.Parse("https://user@example.org/foo?bar=baz")
+	_, ipNet, _ := net.ParseCIDR("10.0.0.0/8")
+	for _, test := range []struct {
+		title  string
+		sprint func(...interface{}) string
+		value  interface{}
+		expect string
+	}{{
+		title:  "big int",
+		sprint: Sprint,
+		value:  i,
+		expect: "123456789012345678901",
+	}, {
+		title:  "big int with types",
+		sprint: Sprintt,
+		value:  i,
+		expect: "*big.Int(123456789012345678901)",
+	}, {
+		title:  "big int, not a pointer",
+		sprint: Sprintt,
+		value:  struct{ i big.Int }{*big.NewInt(42)},
+		expect: "struct{i Int}{i: 42}",
+	}, {
+		title:  "big float",
+		sprint: Sprint,
+		value:  big.NewFloat(1.5),
+		expect: "1.5",
+	}, {
+		title:  "big rat",
+		sprint: Sprintv,
+		value:  big.NewRat(2, 6),
+		expect: "*big.Rat(1/3)",
+	}, {
+		title:  "ip",
+		sprint: Sprintt,
+		value:  net.IPv4(10, 0, 0, 1),
+		expect: "net.IP(10.0.0.1)",
+	}, {
+		title:  "nil ip",
+		sprint: Sprint,
+		value:  struct{ ip net.IP }{},
+		expect: "{ip: nil}",
+	}, {
+		title:  "ip network",
+		sprint: Sprint,
+		value:  ipNet,
+		expect: "10.0.0.0/8",
+	}, {
+		title:  "netip address",
+		sprint: Sprintv,
+		value:  []netip.Addr{netip.MustParseAddr("::1")},
+		expect: "[]Addr{netip.Addr(::1)}",
+	}, {
+		title:  "netip prefix",
+		sprint: Sprint,
+		value:  netip.MustParsePrefix("192.168.0.0/16"),
+		expect: "192.168.0.0/16",
+	}, {
+		title:  "url",
+		sprint: Sprintt,
+		value:  u,
+		expect: "*url.URL(https://user@example.org/foo?bar=baz)",
+	}, {
+		title:  "raw",
+		sprint: Raw.Sprint,
+		value:  []interface{}{time.Second, net.IPv4(10, 0, 0, 1)[12:]},
+		expect: "[]{1000000000, []{0a 00 00 01}}",
+	}, {
+		title:  "raw, selected",
+		sprint: Printer{RawBuiltins: BigBuiltins}.Sprint,
+		value:  []interface{}{big.NewRat(1, 2), net.IPv4(10, 0, 0, 1)},
+		expect: "[]{{a: {neg: false, abs: []{1}}, b: {neg: false, abs: []{2}}}, 10.0.0.1}",
+	}, {
+		title:  "friendly",
+		sprint: Friendly.Sprint,
+		value:  big.NewRat(1, 2),
+		expect: "1/2",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.sprint(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
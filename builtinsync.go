@@ -0,0 +1,190 @@
+package notation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// the maximum number of readers of a sync.RWMutex, as defined in the sync
+// package
+const rwmutexMaxReaders = 1 << 30
+
+func init() {
+	registerBuiltin(SyncBuiltins, sync.Mutex{}, reflectMutex)
+	registerBuiltin(SyncBuiltins, sync.RWMutex{}, reflectRWMutex)
+	registerBuiltin(SyncBuiltins, sync.WaitGroup{}, reflectWaitGroup)
+	registerBuiltin(SyncBuiltins, sync.Once{}, reflectOnce)
+	registerBuiltin(SyncBuiltins, atomic.Bool{}, reflectAtomic)
+	registerBuiltin(SyncBuiltins, atomic.Int32{}, reflectAtomic)
+	registerBuiltin(SyncBuiltins, atomic.Int64{}, reflectAtomic)
+	registerBuiltin(SyncBuiltins, atomic.Uint32{}, reflectAtomic)
+	registerBuiltin(SyncBuiltins, atomic.Uint64{}, reflectAtomic)
+	registerBuiltin(SyncBuiltins, atomic.Uintptr{}, reflectAtomic)
+	registerBuiltin(SyncBuiltins, atomic.Value{}, reflectAtomicValue)
+}
+
+// syncField returns the address of an internal field of the sync types. It
+// searches the field by name and size, including in the nested structs,
+// because the internal structure of these types changes between Go versions.
+func syncField(r reflect.Value, name string, size uintptr) (unsafe.Pointer, bool) {
+	var find func(reflect.Type, uintptr) (uintptr, bool)
+	find = func(t reflect.Type, offset uintptr) (uintptr, bool) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Name == name && f.Type.Size() == size {
+				return offset + f.Offset, true
+			}
+
+			if f.Type.Kind() != reflect.Struct {
+				continue
+			}
+
+			if o, ok := find(f.Type, offset+f.Offset); ok {
+				return o, true
+			}
+		}
+
+		return 0, false
+	}
+
+	offset, ok := find(r.Type(), 0)
+	if !ok {
+		return nil, false
+	}
+
+	p := reflect.ValueOf(pointerTo(r)).UnsafePointer()
+	return unsafe.Add(p, offset), true
+}
+
+func reflectSyncState(o opts, c *Printer, r reflect.Value, state ...interface{}) (node, bool) {
+	n := nodeOf(append(append([]interface{}{"{"}, state...), "}")...)
+	if _, t, _ := withType(o); !t {
+		return n, true
+	}
+
+	return nodeOf(c.builtinTypeName(r.Type()), n), true
+}
+
+func mutexLocked(r reflect.Value) (bool, bool) {
+	state, ok := syncField(r, "state", 4)
+	if !ok {
+		return false, false
+	}
+
+	return atomic.LoadInt32((*int32)(state))&1 != 0, true
+}
+
+func reflectMutex(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	locked, ok := mutexLocked(r)
+	if !ok {
+		return node{}, false
+	}
+
+	if locked {
+		return reflectSyncState(o, c, r, "locked")
+	}
+
+	return reflectSyncState(o, c, r, "unlocked")
+}
+
+func reflectRWMutex(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	readerCount, ok := syncField(r, "readerCount", 4)
+	if !ok {
+		return node{}, false
+	}
+
+	// a negative reader count means that a writer holds or waits for the
+	// lock:
+	//
+	readers := atomic.LoadInt32((*int32)(readerCount))
+	switch {
+	case readers < 0 && readers+rwmutexMaxReaders > 0:
+		return reflectSyncState(o, c, r, "readers: ", readers+rwmutexMaxReaders, ", writer waiting")
+	case readers < 0:
+		return reflectSyncState(o, c, r, "locked")
+	case readers > 0:
+		return reflectSyncState(o, c, r, "readers: ", readers)
+	default:
+		return reflectSyncState(o, c, r, "unlocked")
+	}
+}
+
+func reflectWaitGroup(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	state, ok := syncField(r, "state", 8)
+	if !ok {
+		return node{}, false
+	}
+
+	// the counter is stored in the high 32 bits:
+	counter := int32(atomic.LoadUint64((*uint64)(state)) >> 32)
+	return reflectSyncState(o, c, r, "counter: ", counter)
+}
+
+func reflectOnce(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	done, ok := syncField(r, "done", 4)
+	if !ok {
+		return node{}, false
+	}
+
+	if atomic.LoadUint32((*uint32)(done)) != 0 {
+		return reflectSyncState(o, c, r, "done")
+	}
+
+	return reflectSyncState(o, c, r, "not done")
+}
+
+func reflectAtomic(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	var v interface{}
+	switch a := pointerTo(r).(type) {
+	case *atomic.Bool:
+		v = a.Load()
+	case *atomic.Int32:
+		v = a.Load()
+	case *atomic.Int64:
+		v = a.Load()
+	case *atomic.Uint32:
+		v = a.Load()
+	case *atomic.Uint64:
+		v = a.Load()
+	case *atomic.Uintptr:
+		v = a.Load()
+	}
+
+	return reflectBuiltinText(o, c, r, fmt.Sprint(v))
+}
+
+func reflectLoaded(o opts, c *Printer, p *pending, r reflect.Value, loaded reflect.Value) (node, bool) {
+	var n node
+	if loaded.IsValid() {
+		n = reflectValue(o&^skipTypes, c, p, loaded)
+	} else {
+		n = nodeOf("nil")
+	}
+
+	if _, t, _ := withType(o); !t {
+		return n, true
+	}
+
+	return nodeOf(c.builtinTypeName(r.Type()), "(", wrapper{items: []node{n}}, ")"), true
+}
+
+func reflectAtomicValue(o opts, c *Printer, p *pending, r reflect.Value) (node, bool) {
+	return reflectLoaded(o, c, p, r, reflect.ValueOf(pointerTo(r).(*atomic.Value).Load()))
+}
+
+func isAtomicPointer(t reflect.Type) bool {
+	return t.PkgPath() == "sync/atomic" && strings.HasPrefix(t.Name(), "Pointer[")
+}
+
+func reflectAtomicPointer(o opts, c *Printer, p *pending, r reflect.Value) (node, bool) {
+	loaded := reflect.ValueOf(pointerTo(r)).MethodByName("Load").Call(nil)[0]
+	if loaded.IsNil() {
+		loaded = reflect.Value{}
+	}
+
+	return reflectLoaded(o, c, p, r, loaded)
+}
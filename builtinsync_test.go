@@ -0,0 +1,164 @@
+package notation
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncBuiltins(t *testing.T) {
+	var locked sync.Mutex
+	locked.Lock()
+	defer locked.Unlock()
+
+	var writeLocked, readLocked sync.RWMutex
+	writeLocked.Lock()
+	defer writeLocked.Unlock()
+	readLocked.RLock()
+	readLocked.RLock()
+	defer readLocked.RUnlock()
+	defer readLocked.RUnlock()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	defer wg.Add(-3)
+
+	var once sync.Once
+	once.Do(func() {})
+
+	var i atomic.Int64
+	i.Store(42)
+
+	var v atomic.Value
+	v.Store([]int{1, 2})
+
+	type config struct{ foo int }
+	var p atomic.Pointer[config]
+	p.Store(&config{foo: 42})
+
+	for _, test := range []struct {
+		title  string
+		sprint func(...interface{}) string
+		value  interface{}
+		expect string
+	}{{
+		title:  "unlocked mutex",
+		sprint: Sprintt,
+		value:  &sync.Mutex{},
+		expect: "*sync.Mutex{unlocked}",
+	}, {
+		title:  "locked mutex",
+		sprint: Sprint,
+		value:  &locked,
+		expect: "{locked}",
+	}, {
+		title:  "unlocked rw mutex",
+		sprint: Sprintt,
+		value:  &sync.RWMutex{},
+		expect: "*sync.RWMutex{unlocked}",
+	}, {
+		title:  "write locked rw mutex",
+		sprint: Sprintt,
+		value:  &writeLocked,
+		expect: "*sync.RWMutex{locked}",
+	}, {
+		title:  "read locked rw mutex",
+		sprint: Sprintt,
+		value:  &readLocked,
+		expect: "*sync.RWMutex{readers: 2}",
+	}, {
+		title:  "wait group",
+		sprint: Sprintt,
+		value:  &wg,
+		expect: "*sync.WaitGroup{counter: 3}",
+	}, {
+		title:  "once, done",
+		sprint: Sprintt,
+		value:  &once,
+		expect: "*sync.Once{done}",
+	}, {
+		title:  "once, not done",
+		sprint: Sprint,
+		value:  &sync.Once{},
+		expect: "{not done}",
+	}, {
+		title:  "atomic int",
+		sprint: Sprintt,
+		value:  &i,
+		expect: "*atomic.Int64(42)",
+	}, {
+		title:  "atomic bool",
+		sprint: Sprint,
+		value:  &atomic.Bool{},
+		expect: "false",
+	}, {
+		title:  "atomic value",
+		sprint: Sprintt,
+		value:  &v,
+		expect: "*atomic.Value([]int{1, 2})",
+	}, {
+		title:  "atomic value, nil",
+		sprint: Sprint,
+		value:  &atomic.Value{},
+		expect: "nil",
+	}, {
+		title:  "atomic pointer",
+		sprint: Sprintt,
+		value:  &p,
+		expect: "*atomic.Pointer[notation.config](*config{foo: 42})",
+	}, {
+		title:  "atomic pointer, nil",
+		sprint: Sprintt,
+		value:  &atomic.Pointer[config]{},
+		expect: "*atomic.Pointer[notation.config](nil)",
+	}, {
+		title:  "struct fields",
+		sprint: Sprint,
+		value: &struct {
+			mx    sync.Mutex
+			count atomic.Int32
+		}{},
+		expect: "{mx: {unlocked}, count: 0}",
+	}, {
+		title:  "raw",
+		sprint: Printer{RawBuiltins: SyncBuiltins}.Sprint,
+		value:  &i,
+		expect: "{_: {}, _: {}, v: 42}",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.sprint(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
+
+func TestSyncBuiltinsConcurrent(t *testing.T) {
+	var s struct {
+		mx      sync.Mutex
+		rw      sync.RWMutex
+		counter atomic.Int64
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.mx.Lock()
+				s.rw.RLock()
+				s.counter.Add(1)
+				s.rw.RUnlock()
+				s.mx.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		Sprint(&s, &wg)
+	}
+
+	wg.Wait()
+}
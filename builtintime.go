@@ -0,0 +1,26 @@
+package notation
+
+import (
+	"reflect"
+	"time"
+)
+
+func init() {
+	registerBuiltin(TimeBuiltins, time.Time{}, reflectTime)
+	registerBuiltin(TimeBuiltins, time.Duration(0), reflectStringer)
+	registerBuiltin(TimeBuiltins, time.Location{}, reflectStringer)
+}
+
+func formatTime(t time.Time) string {
+	s := t.Format(time.RFC3339Nano)
+	zone, _ := t.Zone()
+	if zone == "" || zone == "UTC" || zone[0] == '+' || zone[0] == '-' {
+		return s
+	}
+
+	return s + " " + zone
+}
+
+func reflectTime(o opts, c *Printer, _ *pending, r reflect.Value) (node, bool) {
+	return reflectBuiltinText(o, c, r, formatTime(*pointerTo(r).(*time.Time)))
+}
@@ -0,0 +1,96 @@
+package notation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeBuiltins(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	tm := time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)
+	type event struct {
+		at      time.Time
+		took    time.Duration
+		zone    *time.Location
+		Created time.Time
+	}
+
+	for _, test := range []struct {
+		title  string
+		sprint func(...interface{}) string
+		value  interface{}
+		expect string
+	}{{
+		title:  "time",
+		sprint: Sprint,
+		value:  tm,
+		expect: "2021-01-02T03:04:05.000000006Z",
+	}, {
+		title:  "time with zone",
+		sprint: Sprint,
+		value:  tm.In(cet),
+		expect: "2021-01-02T04:04:05.000000006+01:00 CET",
+	}, {
+		title:  "time without zone name",
+		sprint: Sprint,
+		value:  tm.In(time.FixedZone("", -7200)),
+		expect: "2021-01-02T01:04:05.000000006-02:00",
+	}, {
+		title:  "time with types",
+		sprint: Sprintt,
+		value:  tm,
+		expect: "time.Time(2021-01-02T03:04:05.000000006Z)",
+	}, {
+		title:  "duration",
+		sprint: Sprint,
+		value:  time.Hour + 2*time.Minute + 3*time.Second,
+		expect: "1h2m3s",
+	}, {
+		title:  "duration with verbose types",
+		sprint: Sprintv,
+		value:  3 * time.Millisecond,
+		expect: "time.Duration(3ms)",
+	}, {
+		title:  "location",
+		sprint: Sprint,
+		value:  cet,
+		expect: "CET",
+	}, {
+		title:  "location with verbose types",
+		sprint: Sprintv,
+		value:  time.UTC,
+		expect: "*time.Location(UTC)",
+	}, {
+		title:  "unexported fields",
+		sprint: Sprint,
+		value:  event{at: tm, took: time.Second, zone: cet, Created: tm},
+		expect: "{at: 2021-01-02T03:04:05.000000006Z, took: 1s, zone: CET, Created: 2021-01-02T03:04:05.000000006Z}",
+	}, {
+		title:  "unexported fields with moderate types",
+		sprint: Sprintt,
+		value:  event{at: tm, took: time.Second, zone: cet, Created: tm},
+		expect: "event{at: 2021-01-02T03:04:05.000000006Z, took: 1s, zone: CET, Created: 2021-01-02T03:04:05.000000006Z}",
+	}, {
+		title:  "unexported fields with verbose types",
+		sprint: Sprintv,
+		value:  struct{ took time.Duration }{time.Second},
+		expect: "struct{took Duration}{took: time.Duration(1s)}",
+	}, {
+		title:  "in a map",
+		sprint: Sprint,
+		value:  map[string]interface{}{"foo": struct{ at time.Time }{tm}},
+		expect: "map{\"foo\": {at: 2021-01-02T03:04:05.000000006Z}}",
+	}, {
+		title:  "raw",
+		sprint: Printer{RawBuiltins: TimeBuiltins}.Sprint,
+		value:  struct{ took time.Duration }{time.Second},
+		expect: "{took: 1000000000}",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.sprint(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
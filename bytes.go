@@ -0,0 +1,176 @@
+package notation
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BytesMode controls how the byte slices and byte arrays are printed.
+type BytesMode int
+
+const (
+
+	// HexBytes prints the bytes as space separated hexadecimal words, e.g. []{68 65 6c 6c 6f}. This is the
+	// default.
+	HexBytes BytesMode = iota
+
+	// HexdumpBytes prints the bytes in the format of `hexdump -C`, one line per 16 bytes, with the offsets
+	// and the ASCII representation.
+	HexdumpBytes
+
+	// StringBytes prints the bytes as a quoted string, e.g. []byte("hello"). Invalid UTF-8 sequences and
+	// non-printable characters are escaped.
+	StringBytes
+
+	// Base64Bytes prints the bytes as a base64 encoded string, using the standard encoding, e.g.
+	// []byte("aGVsbG8=" /* base64 */).
+	Base64Bytes
+
+	// AutoBytes prints the bytes as a quoted string when they are valid, printable UTF-8, and as hexadecimal
+	// words otherwise. The choice is made for each value separately.
+	AutoBytes
+)
+
+// the struct tag key that can be used to override the byte mode of a single field, e.g.
+// `notation:"bytes=hexdump"`
+const tagKey = "notation"
+
+var bytesModeNames = map[string]BytesMode{
+	"hex":     HexBytes,
+	"hexdump": HexdumpBytes,
+	"string":  StringBytes,
+	"base64":  Base64Bytes,
+	"auto":    AutoBytes,
+}
+
+// tagOption returns the value of a key=value option from the notation struct tag.
+func tagOption(f reflect.StructField, key string) (string, bool) {
+	tag, ok := f.Tag.Lookup(tagKey)
+	if !ok {
+		return "", false
+	}
+
+	for _, o := range strings.Split(tag, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(o), "=")
+		if ok && k == key {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// fieldPrinter returns the printer used for a struct field, applying the overrides from the field tag.
+func fieldPrinter(c *Printer, f reflect.StructField) *Printer {
+	name, ok := tagOption(f, "bytes")
+	if !ok {
+		return c
+	}
+
+	mode, ok := bytesModeNames[name]
+	if !ok || mode == c.Bytes {
+		return c
+	}
+
+	fc := *c
+	fc.Bytes = mode
+	return &fc
+}
+
+func bytesOf(r reflect.Value) []byte {
+	b := make([]byte, r.Len())
+	for i := range b {
+		b[i] = byte(r.Index(i).Uint())
+	}
+
+	return b
+}
+
+func isPrintableText(b []byte) bool {
+	if len(b) == 0 || !utf8.Valid(b) {
+		return false
+	}
+
+	for _, c := range string(b) {
+		if !unicode.IsPrint(c) && c != '\n' && c != '\t' && c != '\r' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hexWords(b []byte) wrapper {
+	w := wrapper{sep: " ", mode: line}
+	for _, bi := range b {
+		w.items = append(w.items, nodeOf(fmt.Sprintf("%02x", bi)))
+	}
+
+	return w
+}
+
+func hexdumpLines(b []byte) wrapper {
+	w := wrapper{sep: " "}
+	for _, l := range strings.Split(strings.TrimSuffix(hex.Dump(b), "\n"), "\n") {
+		if l != "" {
+			w.items = append(w.items, nodeOf(text(l)))
+		}
+	}
+
+	return w
+}
+
+// reflectBytes prints the byte slices and arrays. The prefix is used in the hexadecimal modes, when printing
+// without types.
+func reflectBytes(o opts, c *Printer, prefix string, r reflect.Value) node {
+	b := bytesOf(r)
+	mode := c.Bytes
+	if mode == AutoBytes {
+		mode = HexBytes
+		if isPrintableText(b) {
+			mode = StringBytes
+		}
+	}
+
+	typ := r.Type()
+	_, t, _ := withType(o)
+
+	var n node
+	switch mode {
+	case StringBytes:
+		n = nodeOf("(", wrapper{items: []node{c.quotedString(string(b))}}, ")")
+	case Base64Bytes:
+		b64 := base64.StdEncoding.EncodeToString(b)
+		n = nodeOf("(", wrapper{items: []node{nodeOf(fmt.Sprintf("%q /* base64 */", b64))}}, ")")
+	case HexdumpBytes:
+		return nodeOf(typedPrefix(c, t, prefix, typ), "{", hexdumpLines(b), "}")
+	default:
+		return nodeOf(typedPrefix(c, t, prefix, typ), "{", hexWords(b), "}")
+	}
+
+	// the string representations are always prefixed, otherwise they could not be told apart from the
+	// strings:
+	//
+	if t {
+		return nodeOf(reflectType(c, typ), n)
+	}
+
+	if typ.Kind() == reflect.Array {
+		return nodeOf(fmt.Sprintf("[%d]byte", typ.Len()), n)
+	}
+
+	return nodeOf("[]byte", n)
+}
+
+func typedPrefix(c *Printer, t bool, prefix string, typ reflect.Type) interface{} {
+	if t {
+		return reflectType(c, typ)
+	}
+
+	return prefix
+}
@@ -0,0 +1,92 @@
+package notation
+
+import "testing"
+
+func TestBytesMode(t *testing.T) {
+	type myBytes []byte
+
+	t.Run("hex by default", func(t *testing.T) {
+		const expect = `{b: []{68 69}, a: [2]{01 02}}`
+		s := Printer{}.Sprint(struct {
+			b []byte
+			a [2]byte
+		}{[]byte("hi"), [2]byte{1, 2}})
+
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("hexdump", func(t *testing.T) {
+		const expect = `[]{
+	00000000  54 68 65 20 71 75 69 63  6b 20 62 72 6f 77 6e 20  |The quick brown |
+	00000010  66 6f 78 20 6a 75 6d 70  73 20 6f 76 65 72 20 74  |fox jumps over t|
+	00000020  68 65 20 6c 61 7a 79 20  64 6f 67 2e              |he lazy dog.|
+}`
+
+		defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+		s := Printer{Bytes: HexdumpBytes}.Sprintw([]byte("The quick brown fox jumps over the lazy dog."))
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		const expect = `[]byte("hello\xff")`
+		s := Printer{Bytes: StringBytes}.Sprint([]byte("hello\xff"))
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("string, typed", func(t *testing.T) {
+		const expect = `myBytes("hello")`
+		s := Printer{Bytes: StringBytes}.Sprintt(myBytes("hello"))
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("string, array", func(t *testing.T) {
+		const expect = `[5]byte("hello")`
+		s := Printer{Bytes: StringBytes}.Sprint([5]byte{'h', 'e', 'l', 'l', 'o'})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("base64", func(t *testing.T) {
+		const expect = `[]byte("aGVsbG8=" /* base64 */)`
+		s := Printer{Bytes: Base64Bytes}.Sprint([]byte("hello"))
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		const expect = `[]{[]byte("hello"), []{68 65 6c 6c 6f 00}, []{}}`
+		s := Printer{Bytes: AutoBytes}.Sprint([][]byte{
+			[]byte("hello"),
+			[]byte("hello\x00"),
+			{},
+		})
+
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("field tag", func(t *testing.T) {
+		const expect = `{Header: [2]{00000000  01 02` +
+			`                                             |..|}, Payload: []byte("hello"), Raw: []{68 69}}`
+		s := Printer{}.Sprint(struct {
+			Header  [2]byte `notation:"bytes=hexdump"`
+			Payload []byte  `json:"payload" notation:"bytes=string"`
+			Raw     []byte  `notation:"bytes=unknown"`
+		}{[2]byte{1, 2}, []byte("hello"), []byte("hi")})
+
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package notation
+
+import (
+	"reflect"
+	"sync/atomic"
+	"unsafe"
+)
+
+// peekChan reads the internal state of a channel, without locking it. See
+// the UnsafeChanPeek option.
+func peekChan(r reflect.Value) (closed bool, buffered []reflect.Value) {
+	h := (*hchan)(unsafe.Pointer(r.Pointer()))
+	closed = atomic.LoadUint32(&h.closed) != 0
+	if h.dataqsiz == 0 {
+		return
+	}
+
+	et := r.Type().Elem()
+	for i := uint(0); i < uint(r.Len()); i++ {
+		index := (h.recvx + i) % h.dataqsiz
+		item := unsafe.Add(h.buf, uintptr(index)*uintptr(h.elemsize))
+
+		// take a copy, so that the item can be printed even if it gets
+		// received in the meantime:
+		//
+		v := reflect.New(et).Elem()
+		v.Set(reflect.NewAt(et, item).Elem())
+		buffered = append(buffered, v)
+	}
+
+	return
+}
@@ -0,0 +1,19 @@
+//go:build go1.23
+
+package notation
+
+import "unsafe"
+
+// hchan mirrors the leading fields of the channel type in the runtime
+// package, as of Go 1.23.
+type hchan struct {
+	qcount   uint
+	dataqsiz uint
+	buf      unsafe.Pointer
+	elemsize uint16
+	closed   uint32
+	timer    unsafe.Pointer
+	elemtype unsafe.Pointer
+	sendx    uint
+	recvx    uint
+}
@@ -0,0 +1,95 @@
+package notation
+
+import "testing"
+
+func TestChanState(t *testing.T) {
+	buffered := func(n, c int, closed bool) chan int {
+		ch := make(chan int, c)
+		for i := 0; i < n; i++ {
+			ch <- i + 1
+		}
+
+		if closed {
+			close(ch)
+		}
+
+		return ch
+	}
+
+	// move the receive index, so that the buffered items wrap around the
+	// end of the internal ring buffer:
+	wrapped := buffered(3, 4, false)
+	<-wrapped
+	<-wrapped
+	wrapped <- 4
+	wrapped <- 5
+
+	for _, test := range []struct {
+		title  string
+		sprint func(...interface{}) string
+		value  interface{}
+		expect string
+	}{{
+		title:  "disabled",
+		sprint: Sprint,
+		value:  buffered(3, 10, false),
+		expect: "chan",
+	}, {
+		title:  "len and cap",
+		sprint: Printer{ChanState: true}.Sprint,
+		value:  buffered(3, 10, false),
+		expect: "chan{len: 3, cap: 10}",
+	}, {
+		title:  "len and cap with types",
+		sprint: Printer{ChanState: true}.Sprintt,
+		value:  buffered(3, 10, false),
+		expect: "chan int{len: 3, cap: 10}",
+	}, {
+		title:  "unbuffered",
+		sprint: Printer{ChanState: true}.Sprint,
+		value:  make(chan struct{}),
+		expect: "chan{len: 0, cap: 0}",
+	}, {
+		title:  "nil",
+		sprint: Printer{ChanState: true}.Sprint,
+		value:  struct{ c chan int }{},
+		expect: "{c: nil}",
+	}, {
+		title:  "peek",
+		sprint: Printer{UnsafeChanPeek: true}.Sprint,
+		value:  buffered(3, 10, false),
+		expect: "chan{len: 3, cap: 10, closed: false, buffered: []{1, 2, 3}}",
+	}, {
+		title:  "peek, closed",
+		sprint: Printer{UnsafeChanPeek: true}.Sprintt,
+		value:  buffered(2, 10, true),
+		expect: "chan int{len: 2, cap: 10, closed: true, buffered: []{1, 2}}",
+	}, {
+		title:  "peek, empty",
+		sprint: Printer{UnsafeChanPeek: true}.Sprint,
+		value:  buffered(0, 10, true),
+		expect: "chan{len: 0, cap: 10, closed: true}",
+	}, {
+		title:  "peek, wrapped around",
+		sprint: Printer{UnsafeChanPeek: true}.Sprint,
+		value:  wrapped,
+		expect: "chan{len: 3, cap: 4, closed: false, buffered: []{3, 4, 5}}",
+	}, {
+		title:  "peek, structured items",
+		sprint: Printer{UnsafeChanPeek: true}.Sprint,
+		value: func() chan interface{} {
+			ch := make(chan interface{}, 2)
+			ch <- struct{ foo string }{"bar"}
+			ch <- []int{1, 2}
+			return ch
+		}(),
+		expect: `chan{len: 2, cap: 2, closed: false, buffered: []{{foo: "bar"}, []{1, 2}}}`,
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.sprint(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
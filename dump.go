@@ -0,0 +1,169 @@
+package notation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+type dumpSource struct {
+	fset *token.FileSet
+	file *ast.File
+}
+
+var (
+	dumpSourcesMx sync.Mutex
+	dumpSources   = make(map[string]*dumpSource)
+)
+
+// parseDumpSource parses the source file of the caller. The parsed files are cached, and so are the failures,
+// storing nil.
+func parseDumpSource(path string) *dumpSource {
+	dumpSourcesMx.Lock()
+	defer dumpSourcesMx.Unlock()
+	if src, ok := dumpSources[path]; ok {
+		return src
+	}
+
+	var src *dumpSource
+	fset := token.NewFileSet()
+	if f, err := parser.ParseFile(fset, path, nil, 0); err == nil {
+		src = &dumpSource{fset: fset, file: f}
+	}
+
+	dumpSources[path] = src
+	return src
+}
+
+// dumpArgOffset returns the index of the first dumped argument for the calls of the dump functions, or -1.
+func dumpArgOffset(call *ast.CallExpr) int {
+	var name string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	}
+
+	switch name {
+	case "Dump", "Sdump":
+		return 0
+	case "Fdump":
+		return 1
+	default:
+		return -1
+	}
+}
+
+// dumpLabels finds the call of the dump functions in the caller's source at the given line, and returns the
+// source expressions of the arguments. When the source is not available, or the call cannot be identified
+// unambiguously, it returns nil.
+func dumpLabels(path string, line, count int) []string {
+	src := parseDumpSource(path)
+	if src == nil {
+		return nil
+	}
+
+	var labels []string
+	var found int
+	ast.Inspect(src.file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || src.fset.Position(call.Lparen).Line != line || call.Ellipsis.IsValid() {
+			return true
+		}
+
+		offset := dumpArgOffset(call)
+		if offset < 0 || len(call.Args)-offset != count {
+			return true
+		}
+
+		found++
+		labels = nil
+		for _, arg := range call.Args[offset:] {
+			labels = append(labels, gotypes.ExprString(arg))
+		}
+
+		return true
+	})
+
+	if found != 1 {
+		return nil
+	}
+
+	return labels
+}
+
+func (pr Printer) dump(w io.Writer, v []interface{}) (int, error) {
+	// skipping dump and the exported function:
+	_, path, line, ok := runtime.Caller(2)
+
+	var prefix string
+	var labels []string
+	if ok {
+		prefix = fmt.Sprintf("%s:%d: ", filepath.Base(path), line)
+		labels = dumpLabels(path, line, len(v))
+	}
+
+	var b strings.Builder
+	for i, vi := range v {
+		label := fmt.Sprintf("arg%d", i)
+		if labels != nil {
+			label = labels[i]
+		}
+
+		b.WriteString(prefix)
+		b.WriteString(label)
+		b.WriteString(" = ")
+		b.WriteString(pr.sprintValues(wrap, []interface{}{vi}))
+		b.WriteString("\n")
+	}
+
+	return io.WriteString(w, b.String())
+}
+
+// Dump prints the provided objects to stderr, each on its own line, labeled with the source location of the
+// call and with the expression of the argument, e.g. main.go:42: req.Header = map{...}. The objects are
+// wrapped (and indented) where necessary. When the source file of the caller is not available, the
+// arguments are labeled by their position, e.g. arg0.
+func Dump(v ...interface{}) (int, error) {
+	return Printer{}.dump(stderr, v)
+}
+
+// Fdump prints the provided objects to the provided writer the same way as Dump.
+func Fdump(w io.Writer, v ...interface{}) (int, error) {
+	return Printer{}.dump(w, v)
+}
+
+// Sdump returns the string representation of the provided objects the same way as Dump prints them.
+func Sdump(v ...interface{}) string {
+	var b strings.Builder
+	Printer{}.dump(&b, v)
+	return b.String()
+}
+
+// Dump prints the provided objects to stderr the same way as the package level Dump function, with the
+// options of the printer.
+func (pr Printer) Dump(v ...interface{}) (int, error) {
+	return pr.dump(stderr, v)
+}
+
+// Fdump prints the provided objects to the provided writer the same way as Dump, with the options of the
+// printer.
+func (pr Printer) Fdump(w io.Writer, v ...interface{}) (int, error) {
+	return pr.dump(w, v)
+}
+
+// Sdump returns the string representation of the provided objects the same way as Dump prints them, with
+// the options of the printer.
+func (pr Printer) Sdump(v ...interface{}) string {
+	var b strings.Builder
+	pr.dump(&b, v)
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package notation
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	type request struct {
+		Method string
+		Header map[string][]string
+	}
+
+	req := request{Method: "GET", Header: map[string][]string{"Accept": {"*/*"}}}
+	count := 3
+
+	t.Run("labels from the source", func(t *testing.T) {
+		expect := regexp.MustCompile(`^dump_test\.go:[0-9]+: req\.Header = map{"Accept": \[\]{"\*/\*"}}
+dump_test\.go:[0-9]+: count \+ 1 = 4
+$`)
+
+		var b bytes.Buffer
+		Fdump(&b, req.Header, count+1)
+		if s := b.String(); !expect.MatchString(s) {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("sdump", func(t *testing.T) {
+		expect := regexp.MustCompile(`^dump_test\.go:[0-9]+: req\.Method = "GET"
+$`)
+
+		s := Sdump(req.Method)
+		if !expect.MatchString(s) {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		expect := regexp.MustCompile(`^dump_test\.go:[0-9]+: req = {
+	Method: "GET",
+	Header: map{
+		"Accept": \[\]{
+			"\*/\*",
+		},
+	},
+}
+$`)
+
+		defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+		s := Sdump(req)
+		if !expect.MatchString(s) {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("printer", func(t *testing.T) {
+		expect := regexp.MustCompile(`^dump_test\.go:[0-9]+: req = {Method: "GET", Header: map{...}}
+$`)
+
+		s := Printer{MaxDepth: 1}.Sdump(req)
+		if !expect.MatchString(s) {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("positional labels when the call is not recognized", func(t *testing.T) {
+		expect := regexp.MustCompile(`^dump_test\.go:[0-9]+: arg0 = "GET"
+dump_test\.go:[0-9]+: arg1 = 3
+$`)
+
+		var b bytes.Buffer
+		dump := Fdump
+		dump(&b, req.Method, count)
+		if s := b.String(); !expect.MatchString(s) {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("positional labels when the call is ambiguous", func(t *testing.T) {
+		dumps := []string{Sdump(count), Sdump(count)}
+		if s := strings.Join(dumps, ""); strings.Count(s, "arg0 = 3") != 2 {
+			t.Fatalf("expected: positional labels, got: %s", s)
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package notation
+
+import (
+	"math"
+	"math/cmplx"
+	"reflect"
+	"sort"
+)
+
+// EqualOption configures the comparison of Equal.
+type EqualOption func(*equalConfig)
+
+type equalConfig struct {
+	ignorePaths      map[string]bool
+	ignoreTags       map[[2]string]bool
+	epsilon          float64
+	nilEqualsEmpty   bool
+	unorderedSlices  bool
+	ignoreUnexported bool
+}
+
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type equalState struct {
+	config  equalConfig
+	visited map[visit]bool
+	diffs   []string
+}
+
+// IgnorePaths skips the values at the specified paths, e.g. .Metadata.Generation or .Items[0]["key"]. The
+// paths use the same format as the differences returned by Equal.
+func IgnorePaths(paths ...string) EqualOption {
+	return func(c *equalConfig) {
+		for _, p := range paths {
+			c.ignorePaths[p] = true
+		}
+	}
+}
+
+// IgnoreTag skips the struct fields that have the specified tag value, e.g. IgnoreTag("json", "-").
+func IgnoreTag(key, value string) EqualOption {
+	return func(c *equalConfig) {
+		c.ignoreTags[[2]string{key, value}] = true
+	}
+}
+
+// FloatEpsilon considers the floating point numbers equal, when their difference is not greater than
+// epsilon. For complex numbers, it is applied to the absolute value of the difference.
+func FloatEpsilon(epsilon float64) EqualOption {
+	return func(c *equalConfig) {
+		c.epsilon = epsilon
+	}
+}
+
+// NilEqualsEmpty considers the nil and the empty slices and maps equal.
+func NilEqualsEmpty() EqualOption {
+	return func(c *equalConfig) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// UnorderedSlices compares the slices ignoring the order of their items. Arrays are always compared in order.
+func UnorderedSlices() EqualOption {
+	return func(c *equalConfig) {
+		c.unorderedSlices = true
+	}
+}
+
+// IgnoreUnexported skips the unexported struct fields. By default, they are compared, too.
+func IgnoreUnexported() EqualOption {
+	return func(c *equalConfig) {
+		c.ignoreUnexported = true
+	}
+}
+
+// Equal compares two Go objects deeply, similar to reflect.DeepEqual, including the handling of the cyclic
+// references, but with the comparison controlled by the options. Besides the result, it returns the paths
+// where the objects are different, e.g. .Spec.Ports[1] or .Labels["app"]. The path of the root objects is
+// the empty string. When the items of the unordered slices don't match, the path of the slice is returned.
+func Equal(a, b interface{}, opts ...EqualOption) (bool, []string) {
+	s := &equalState{
+		config: equalConfig{
+			ignorePaths: make(map[string]bool),
+			ignoreTags:  make(map[[2]string]bool),
+		},
+		visited: make(map[visit]bool),
+	}
+
+	for _, o := range opts {
+		o(&s.config)
+	}
+
+	s.equal("", reflect.ValueOf(a), reflect.ValueOf(b), true)
+	return len(s.diffs) == 0, s.diffs
+}
+
+func (s *equalState) diff(path string, record bool) bool {
+	if record {
+		s.diffs = append(s.diffs, path)
+	}
+
+	return false
+}
+
+// equal compares a and b, and, when record is true, it records the paths of the differences. It doesn't
+// record them when it is used to match the items of the unordered slices.
+func (s *equalState) equal(path string, a, b reflect.Value, record bool) bool {
+	if s.config.ignorePaths[path] {
+		return true
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() == b.IsValid() {
+			return true
+		}
+
+		return s.diff(path, record)
+	}
+
+	if a.Type() != b.Type() {
+		return s.diff(path, record)
+	}
+
+	switch a.Kind() {
+	case reflect.Map, reflect.Ptr, reflect.Slice:
+		if s.config.nilEqualsEmpty && a.Kind() != reflect.Ptr && a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() && b.IsNil() {
+				return true
+			}
+
+			return s.diff(path, record)
+		}
+
+		// cyclic references, similar to reflect.DeepEqual. Only the comparisons in progress are tracked,
+		// because the failed attempts of matching the unordered items must not be remembered as equal:
+		//
+		v := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+		if s.visited[v] {
+			return true
+		}
+
+		s.visited[v] = true
+		defer delete(s.visited, v)
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		if a.Bool() != b.Bool() {
+			return s.diff(path, record)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if a.Int() != b.Int() {
+			return s.diff(path, record)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if a.Uint() != b.Uint() {
+			return s.diff(path, record)
+		}
+	case reflect.Float32, reflect.Float64:
+		if a.Float() != b.Float() && !(math.Abs(a.Float()-b.Float()) <= s.config.epsilon) {
+			return s.diff(path, record)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if a.Complex() != b.Complex() && !(cmplx.Abs(a.Complex()-b.Complex()) <= s.config.epsilon) {
+			return s.diff(path, record)
+		}
+	case reflect.String:
+		if a.String() != b.String() {
+			return s.diff(path, record)
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			return s.diff(path, record)
+		}
+	case reflect.Func:
+		// like reflect.DeepEqual, only the nil functions are equal:
+		if !a.IsNil() || !b.IsNil() {
+			return s.diff(path, record)
+		}
+	case reflect.Interface, reflect.Ptr:
+		return s.equal(path, a.Elem(), b.Elem(), record)
+	case reflect.Array:
+		return s.equalItems(path, a, b, record)
+	case reflect.Slice:
+		if s.config.unorderedSlices {
+			return s.equalUnordered(path, a, b, record)
+		}
+
+		return s.equalItems(path, a, b, record)
+	case reflect.Map:
+		return s.equalMaps(path, a, b, record)
+	case reflect.Struct:
+		return s.equalStructs(path, a, b, record)
+	}
+
+	return true
+}
+
+func (s *equalState) equalItems(path string, a, b reflect.Value, record bool) bool {
+	eq := true
+	for i := 0; i < a.Len() || i < b.Len(); i++ {
+		ip := indexPath(path, i)
+		if i >= a.Len() || i >= b.Len() {
+			if s.config.ignorePaths[ip] {
+				continue
+			}
+
+			eq = s.diff(ip, record)
+			continue
+		}
+
+		if !s.equal(ip, a.Index(i), b.Index(i), record) {
+			eq = false
+		}
+	}
+
+	return eq
+}
+
+func (s *equalState) equalUnordered(path string, a, b reflect.Value, record bool) bool {
+	if a.Len() != b.Len() {
+		return s.diff(path, record)
+	}
+
+	matched := make([]bool, b.Len())
+	for i := 0; i < a.Len(); i++ {
+		var found bool
+		for j := 0; j < b.Len(); j++ {
+			if !matched[j] && s.equal(indexPath(path, i), a.Index(i), b.Index(j), false) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return s.diff(path, record)
+		}
+	}
+
+	return true
+}
+
+type mapKey struct {
+	path string
+	key  reflect.Value
+}
+
+// equalMaps compares the entries of the maps. The keys are identified by their value, and their printed
+// form is used only as the path, because different keys can be printed the same way, e.g. 1 and int64(1)
+// in a map[interface{}]int.
+func (s *equalState) equalMaps(path string, a, b reflect.Value, record bool) bool {
+	var keys []mapKey
+	for _, key := range a.MapKeys() {
+		keys = append(keys, mapKey{path: keyPath(path, key), key: key})
+	}
+
+	for _, key := range b.MapKeys() {
+		if !a.MapIndex(key).IsValid() {
+			keys = append(keys, mapKey{path: keyPath(path, key), key: key})
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].path < keys[j].path })
+	eq := true
+	for _, k := range keys {
+		kp, key := k.path, k.key
+		av, bv := a.MapIndex(key), b.MapIndex(key)
+		if !av.IsValid() || !bv.IsValid() {
+			if s.config.ignorePaths[kp] {
+				continue
+			}
+
+			eq = s.diff(kp, record)
+			continue
+		}
+
+		if !s.equal(kp, av, bv, record) {
+			eq = false
+		}
+	}
+
+	return eq
+}
+
+func (s *equalState) ignoreField(f reflect.StructField) bool {
+	if s.config.ignoreUnexported && f.PkgPath != "" {
+		return true
+	}
+
+	for tag := range s.config.ignoreTags {
+		if v, ok := f.Tag.Lookup(tag[0]); ok && v == tag[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *equalState) equalStructs(path string, a, b reflect.Value, record bool) bool {
+	eq := true
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if s.ignoreField(f) {
+			continue
+		}
+
+		if !s.equal(fieldPath(path, f.Name), a.Field(i), b.Field(i), record) {
+			eq = false
+		}
+	}
+
+	return eq
+}
@@ -0,0 +1,167 @@
+package notation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	type port struct {
+		Name string
+		Port int
+	}
+
+	type spec struct {
+		Name       string
+		Ports      []port
+		Labels     map[string]string
+		Weight     float64
+		Generation int `json:"-"`
+		revision   int
+	}
+
+	// not a constant expression, to get the rounding error:
+	tenth := 0.1
+
+	base := func() spec {
+		return spec{
+			Name:       "foo",
+			Ports:      []port{{"http", 80}, {"https", 443}},
+			Labels:     map[string]string{"app": "foo"},
+			Weight:     0.3,
+			Generation: 1,
+			revision:   2,
+		}
+	}
+
+	for _, test := range []struct {
+		title  string
+		change func(*spec)
+		opts   []EqualOption
+		expect []string
+	}{{
+		title:  "equal",
+		change: func(*spec) {},
+	}, {
+		title:  "field",
+		change: func(s *spec) { s.Name = "bar" },
+		expect: []string{".Name"},
+	}, {
+		title:  "list item",
+		change: func(s *spec) { s.Ports[1].Port = 8443 },
+		expect: []string{".Ports[1].Port"},
+	}, {
+		title:  "list length",
+		change: func(s *spec) { s.Ports = s.Ports[:1] },
+		expect: []string{".Ports[1]"},
+	}, {
+		title:  "map entries",
+		change: func(s *spec) { s.Labels = map[string]string{"app": "bar", "tier": "web"} },
+		expect: []string{`.Labels["app"]`, `.Labels["tier"]`},
+	}, {
+		title:  "ignore path",
+		change: func(s *spec) { s.Name = "bar"; s.Labels["app"] = "bar" },
+		opts:   []EqualOption{IgnorePaths(".Name", `.Labels["app"]`)},
+	}, {
+		title:  "ignore tag",
+		change: func(s *spec) { s.Generation = 2 },
+		opts:   []EqualOption{IgnoreTag("json", "-")},
+	}, {
+		title:  "float",
+		change: func(s *spec) { s.Weight = tenth + 0.2 },
+		expect: []string{".Weight"},
+	}, {
+		title:  "float epsilon",
+		change: func(s *spec) { s.Weight = tenth + 0.2 },
+		opts:   []EqualOption{FloatEpsilon(1e-9)},
+	}, {
+		title:  "nil and empty",
+		change: func(s *spec) { s.Labels = map[string]string{} },
+		expect: []string{`.Labels["app"]`},
+	}, {
+		title:  "unordered",
+		change: func(s *spec) { s.Ports[0], s.Ports[1] = s.Ports[1], s.Ports[0] },
+		opts:   []EqualOption{UnorderedSlices()},
+	}, {
+		title:  "unordered, different",
+		change: func(s *spec) { s.Ports[0], s.Ports[1] = s.Ports[1], port{"http", 8080} },
+		opts:   []EqualOption{UnorderedSlices()},
+		expect: []string{".Ports"},
+	}, {
+		title:  "unexported",
+		change: func(s *spec) { s.revision = 3 },
+		expect: []string{".revision"},
+	}, {
+		title:  "ignore unexported",
+		change: func(s *spec) { s.revision = 3 },
+		opts:   []EqualOption{IgnoreUnexported()},
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			a, b := base(), base()
+			test.change(&b)
+			eq, diffs := Equal(a, b, test.opts...)
+			if eq != (len(test.expect) == 0) || strings.Join(diffs, ", ") != strings.Join(test.expect, ", ") {
+				t.Fatalf("expected: %v, got: %t, %v", test.expect, eq, diffs)
+			}
+		})
+	}
+
+	t.Run("nil equals empty", func(t *testing.T) {
+		eq, diffs := Equal(spec{}, spec{Ports: []port{}, Labels: map[string]string{}}, NilEqualsEmpty())
+		if !eq {
+			t.Fatalf("expected: equal, got: %v", diffs)
+		}
+	})
+
+	t.Run("different types", func(t *testing.T) {
+		eq, diffs := Equal(42, "42")
+		if eq || len(diffs) != 1 || diffs[0] != "" {
+			t.Fatalf("expected: root difference, got: %v", diffs)
+		}
+	})
+
+	t.Run("keys printed the same way", func(t *testing.T) {
+		type key struct{ ID int }
+		k1, k2 := &key{1}, &key{1}
+		for _, test := range []struct {
+			title string
+			a, b  interface{}
+		}{{
+			title: "different types",
+			a:     map[interface{}]int{1: 1, int64(1): 2},
+			b:     map[interface{}]int{1: 1, int64(1): 3},
+		}, {
+			title: "different pointers",
+			a:     map[*key]int{k1: 1, k2: 2},
+			b:     map[*key]int{k1: 1, k2: 3},
+		}} {
+			t.Run(test.title, func(t *testing.T) {
+				// the map iteration order is random:
+				for i := 0; i < 16; i++ {
+					if eq, diffs := Equal(test.a, test.b); eq || len(diffs) != 1 {
+						t.Fatalf("expected: one difference, got: %t, %v", eq, diffs)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+		type node struct {
+			Next  *node
+			Value int
+		}
+
+		a, b := &node{Value: 1}, &node{Value: 1}
+		a.Next, b.Next = a, b
+		if eq, diffs := Equal(a, b); !eq {
+			t.Fatalf("expected: equal, got: %v", diffs)
+		}
+
+		c := &node{Value: 1}
+		c.Next = &node{Value: 2, Next: c}
+		if eq, diffs := Equal(a, c); eq || strings.Join(diffs, ", ") != ".Next.Value" {
+			t.Fatalf("expected: .Next.Value, got: %v", diffs)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package notation
+
+import "fmt"
+
+type formatter struct {
+	printer Printer
+	value   interface{}
+}
+
+// Value wraps a Go object, so that it is printed with notation when used with the fmt functions, e.g. in
+// log.Printf("state: %v", notation.Value(x)). The %v and %s verbs print it on a single line, the + flag
+// enables wrapping, and the # flag verbose type information. The width sets the line width used for
+// wrapping, and the precision the maximum depth, e.g. %+100.3v.
+func Value(v interface{}) fmt.Formatter {
+	return Printer{}.Value(v)
+}
+
+// Value wraps a Go object, so that it is printed with the options of the printer when used with the fmt
+// functions. See the package level Value function for the supported verbs and flags.
+func (pr Printer) Value(v interface{}) fmt.Formatter {
+	return formatter{printer: pr, value: v}
+}
+
+// Format implements fmt.Formatter. It writes directly to the fmt.State, without an intermediate string.
+func (f formatter) Format(s fmt.State, verb rune) {
+	pr := f.printer
+	if w, ok := s.Width(); ok {
+		pr.LineWidth = w
+	}
+
+	if p, ok := s.Precision(); ok {
+		pr.MaxDepth = p
+	}
+
+	o := none
+	if s.Flag('+') {
+		o |= wrap
+	}
+
+	if s.Flag('#') {
+		o |= allTypes
+	}
+
+	switch verb {
+	case 'v', 's':
+		pr.fprintValues(s, o, []interface{}{f.value})
+	default:
+		fmt.Fprintf(s, "%%!%c(", verb)
+		pr.fprintValues(s, o, []interface{}{f.value})
+		fmt.Fprint(s, ")")
+	}
+}
@@ -0,0 +1,77 @@
+package notation
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValue(t *testing.T) {
+	type inner struct {
+		Foo []int
+		Bar map[string]int
+	}
+
+	type outer struct {
+		Foo int
+		Bar inner
+	}
+
+	o := outer{Foo: 1, Bar: inner{Foo: []int{1, 2}, Bar: map[string]int{"baz": 3}}}
+
+	for _, test := range []struct {
+		format string
+		value  interface{}
+		expect string
+	}{{
+		format: "%v",
+		value:  Value(o),
+		expect: `{Foo: 1, Bar: {Foo: []{1, 2}, Bar: map{"baz": 3}}}`,
+	}, {
+		format: "%s",
+		value:  Value(o),
+		expect: `{Foo: 1, Bar: {Foo: []{1, 2}, Bar: map{"baz": 3}}}`,
+	}, {
+		format: "%+20v",
+		value:  Value(o),
+		expect: `{
+	Foo: 1,
+	Bar: {
+		Foo: []{
+			1,
+			2,
+		},
+		Bar: map{
+			"baz": 3,
+		},
+	},
+}`,
+	}, {
+		format: "%#v",
+		value:  Value(o),
+		expect: `outer{Foo: int(1), Bar: inner{Foo: []int{int(1), int(2)}, Bar: map[string]int{string("baz"): int(3)}}}`,
+	}, {
+		format: "%.1v",
+		value:  Value(o),
+		expect: `{Foo: 1, Bar: {...}}`,
+	}, {
+		format: "state: %v",
+		value:  Value(nil),
+		expect: `state: nil`,
+	}, {
+		format: "%d",
+		value:  Value(42),
+		expect: `%!d(42)`,
+	}, {
+		format: "%v",
+		value:  Printer{Compact: true}.Value(o),
+		expect: `{Foo:1,Bar:{Foo:[]{1,2},Bar:map{"baz":3}}}`,
+	}} {
+		t.Run(test.format, func(t *testing.T) {
+			defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+			s := fmt.Sprintf(test.format, test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
@@ -343,7 +343,7 @@ func fprintWrapper(w *writer, t int, wrap bool, wr wrapper) {
 	if !wrap {
 		for i, ni := range wr.items {
 			if i > 0 {
-				w.write(wr.sep)
+				w.writePart(wr.sep)
 			}
 
 			fprint(w, t, ni)
@@ -401,7 +401,7 @@ func fprint(w *writer, t int, n node) {
 		case wrapper:
 			fprintWrapper(w, t, n.wrap, part)
 		default:
-			w.write(part)
+			w.writePart(part)
 		}
 	}
 }
@@ -0,0 +1,42 @@
+package notation
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// matches the names generated for closures, e.g. main.handleLogin.func1, or
+// main.handleLogin.func1.2 for nested closures:
+var closureName = regexp.MustCompile(`^(.+)\.(func[0-9]+(\.[0-9]+)*)$`)
+
+func funcName(name string) string {
+	// method values:
+	name = strings.TrimSuffix(name, "-fm")
+
+	// the package path may contain dots, we need to match only the part
+	// after the last slash:
+	i := strings.LastIndexByte(name, '/') + 1
+	m := closureName.FindStringSubmatch(name[i:])
+	if m == nil {
+		return name
+	}
+
+	return fmt.Sprintf("%s of %s%s", m[2], name[:i], m[1])
+}
+
+func funcInfo(pc uintptr) string {
+	f := runtime.FuncForPC(pc)
+	if f == nil {
+		return ""
+	}
+
+	file, line := f.FileLine(f.Entry())
+	if file == "" {
+		return funcName(f.Name())
+	}
+
+	return fmt.Sprintf("%s at %s:%d", funcName(f.Name()), filepath.Base(file), line)
+}
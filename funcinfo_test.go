@@ -0,0 +1,74 @@
+package notation
+
+import (
+	"regexp"
+	"testing"
+)
+
+type funcInfoTest struct{}
+
+func testFuncInfo() {}
+
+func (funcInfoTest) method() {}
+
+func TestFuncName(t *testing.T) {
+	for _, test := range []struct {
+		name, expect string
+	}{
+		{"main.handleLogin", "main.handleLogin"},
+		{"main.handleLogin.func1", "func1 of main.handleLogin"},
+		{"main.handleLogin.func1.2", "func1.2 of main.handleLogin"},
+		{"main.(*server).handleLogin-fm", "main.(*server).handleLogin"},
+		{"github.com/acme/app.v2/server.handleLogin", "github.com/acme/app.v2/server.handleLogin"},
+		{"github.com/acme/app.v2/server.handleLogin.func3", "func3 of github.com/acme/app.v2/server.handleLogin"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := funcName(test.name)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
+
+func TestFuncNames(t *testing.T) {
+	closure := func(int) string { return "" }
+	for _, test := range []struct {
+		title  string
+		sprint func(...interface{}) string
+		value  interface{}
+		expect string
+	}{{
+		title:  "disabled",
+		sprint: Sprint,
+		value:  testFuncInfo,
+		expect: `^func\(\)$`,
+	}, {
+		title:  "function",
+		sprint: Printer{FuncNames: true}.Sprint,
+		value:  testFuncInfo,
+		expect: `^func\(\) /\* github\.com/aryszka/notation\.testFuncInfo at funcinfo_test\.go:[0-9]+ \*/$`,
+	}, {
+		title:  "closure",
+		sprint: Printer{FuncNames: true}.Sprintt,
+		value:  closure,
+		expect: `^func\(int\) string /\* func1 of github\.com/aryszka/notation\.TestFuncNames at funcinfo_test\.go:[0-9]+ \*/$`,
+	}, {
+		title:  "method value",
+		sprint: Printer{FuncNames: true}.Sprint,
+		value:  funcInfoTest{}.method,
+		expect: `^func\(\) /\* github\.com/aryszka/notation\.funcInfoTest\.method at .+:[0-9]+ \*/$`,
+	}, {
+		title:  "nil",
+		sprint: Printer{FuncNames: true}.Sprint,
+		value:  struct{ f func() }{},
+		expect: `^{f: nil}$`,
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.sprint(test.value)
+			if !regexp.MustCompile(test.expect).MatchString(s) {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
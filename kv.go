@@ -0,0 +1,186 @@
+package notation
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+)
+
+// badKey is used, similar to log/slog, as the key of those values that are not preceded by a string key.
+const badKey = "!BADKEY"
+
+type kvPair struct {
+	key   string
+	value interface{}
+}
+
+func kvPairs(kv []interface{}) []kvPair {
+	var pairs []kvPair
+	for len(kv) > 0 {
+		key, ok := kv[0].(string)
+		if !ok || len(kv) == 1 {
+			pairs = append(pairs, kvPair{key: badKey, value: kv[0]})
+			kv = kv[1:]
+			continue
+		}
+
+		pairs = append(pairs, kvPair{key: key, value: kv[1]})
+		kv = kv[2:]
+	}
+
+	return pairs
+}
+
+// fprintPairs prints the key-value pairs. The values share the reference tracking, and the shared references
+// are always detected, and so when a value is reachable from another value, it is printed as a reference.
+func (pr Printer) fprintPairs(w io.Writer, o opts, kv []interface{}) (int, error) {
+	pr.SharedRefs = true
+	o, l := pr.setup(o, kv)
+	p := newPending()
+	pairs := kvPairs(kv)
+	keys := make([]string, len(pairs))
+	nodes := make([]node, len(pairs))
+	for i, pair := range pairs {
+		keys[i] = pair.key
+		nodes[i] = nodeOf("nil")
+		if pair.value != nil {
+			nodes[i] = reflectValue(o, &pr, p, reflect.ValueOf(pair.value))
+		}
+	}
+
+	return l.fprintLabeled(pr.newWriter(w), o, keys, nodes)
+}
+
+// fprintLabeled prints the nodes labeled with the keys, separated by a comma, or, when wrapping, by a
+// newline. The nodes are expected to be complete before printing any of them, because the labels of the
+// shared references are printed only when a later node refers to them.
+func (l layout) fprintLabeled(wr *writer, o opts, keys []string, nodes []node) (int, error) {
+	for i, n := range nodes {
+		if wr.err != nil {
+			return wr.n, wr.err
+		}
+
+		if i > 0 {
+			if o&wrap == 0 {
+				wr.writePart(", ")
+			} else {
+				wr.write("\n")
+			}
+		}
+
+		// the key is a value, not syntax, and it needs its own node, being an str:
+		l.fprintNode(wr, o, nodeOf(nodeOf(str{val: keys[i]}), ": ", n))
+	}
+
+	return wr.n, wr.err
+}
+
+func (pr Printer) printlnPairs(o opts, kv []interface{}) (int, error) {
+	n, err := pr.fprintPairs(stderr, o, kv)
+	if err != nil {
+		return n, err
+	}
+
+	nn, err := stderr.Write([]byte("\n"))
+	return n + nn, err
+}
+
+func (pr Printer) sprintPairs(o opts, kv []interface{}) string {
+	var b bytes.Buffer
+	pr.fprintPairs(&b, o, kv)
+	return b.String()
+}
+
+// Fprintkv prints the provided key-value pairs to the provided writer, e.g. user: {name: "foo"}, count: 3.
+// The keys are expected to be strings. The values without a string key are printed with the key !BADKEY.
+func Fprintkv(w io.Writer, kv ...interface{}) (int, error) {
+	return Printer{}.Fprintkv(w, kv...)
+}
+
+// Fprintkvw prints the provided key-value pairs to the provided writer, each pair on a separate line, with
+// wrapping (and indentation) where necessary.
+func Fprintkvw(w io.Writer, kv ...interface{}) (int, error) {
+	return Printer{}.Fprintkvw(w, kv...)
+}
+
+// Printkv prints the provided key-value pairs to stderr, e.g. user: {name: "foo"}, count: 3. The keys are
+// expected to be strings. The values without a string key are printed with the key !BADKEY.
+func Printkv(kv ...interface{}) (int, error) {
+	return Printer{}.Printkv(kv...)
+}
+
+// Printkvw prints the provided key-value pairs to stderr, each pair on a separate line, with wrapping (and
+// indentation) where necessary.
+func Printkvw(kv ...interface{}) (int, error) {
+	return Printer{}.Printkvw(kv...)
+}
+
+// Printlnkv prints the provided key-value pairs to stderr with a closing newline.
+func Printlnkv(kv ...interface{}) (int, error) {
+	return Printer{}.Printlnkv(kv...)
+}
+
+// Printlnkvw prints the provided key-value pairs to stderr with a closing newline, each pair on a separate
+// line, with wrapping (and indentation) where necessary.
+func Printlnkvw(kv ...interface{}) (int, error) {
+	return Printer{}.Printlnkvw(kv...)
+}
+
+// Sprintkv returns the string representation of the provided key-value pairs, e.g. user: {name: "foo"},
+// count: 3. The keys are expected to be strings. The values without a string key are printed with the key
+// !BADKEY.
+func Sprintkv(kv ...interface{}) string {
+	return Printer{}.Sprintkv(kv...)
+}
+
+// Sprintkvw returns the string representation of the provided key-value pairs, each pair on a separate line,
+// with wrapping (and indentation) where necessary.
+func Sprintkvw(kv ...interface{}) string {
+	return Printer{}.Sprintkvw(kv...)
+}
+
+// Fprintkv prints the provided key-value pairs to the provided writer, with the options of the printer.
+func (pr Printer) Fprintkv(w io.Writer, kv ...interface{}) (int, error) {
+	return pr.fprintPairs(w, none, kv)
+}
+
+// Fprintkvw prints the provided key-value pairs to the provided writer, each pair on a separate line, with
+// wrapping (and indentation) where necessary, and with the options of the printer.
+func (pr Printer) Fprintkvw(w io.Writer, kv ...interface{}) (int, error) {
+	return pr.fprintPairs(w, wrap, kv)
+}
+
+// Printkv prints the provided key-value pairs to stderr, with the options of the printer.
+func (pr Printer) Printkv(kv ...interface{}) (int, error) {
+	return pr.fprintPairs(stderr, none, kv)
+}
+
+// Printkvw prints the provided key-value pairs to stderr, each pair on a separate line, with wrapping (and
+// indentation) where necessary, and with the options of the printer.
+func (pr Printer) Printkvw(kv ...interface{}) (int, error) {
+	return pr.fprintPairs(stderr, wrap, kv)
+}
+
+// Printlnkv prints the provided key-value pairs to stderr with a closing newline, with the options of the
+// printer.
+func (pr Printer) Printlnkv(kv ...interface{}) (int, error) {
+	return pr.printlnPairs(none, kv)
+}
+
+// Printlnkvw prints the provided key-value pairs to stderr with a closing newline, each pair on a separate
+// line, with wrapping (and indentation) where necessary, and with the options of the printer.
+func (pr Printer) Printlnkvw(kv ...interface{}) (int, error) {
+	return pr.printlnPairs(wrap, kv)
+}
+
+// Sprintkv returns the string representation of the provided key-value pairs, with the options of the
+// printer.
+func (pr Printer) Sprintkv(kv ...interface{}) string {
+	return pr.sprintPairs(none, kv)
+}
+
+// Sprintkvw returns the string representation of the provided key-value pairs, each pair on a separate line,
+// with wrapping (and indentation) where necessary, and with the options of the printer.
+func (pr Printer) Sprintkvw(kv ...interface{}) string {
+	return pr.sprintPairs(wrap, kv)
+}
@@ -0,0 +1,87 @@
+package notation
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKV(t *testing.T) {
+	type user struct {
+		Name   string
+		Groups []string
+	}
+
+	for _, test := range []struct {
+		title  string
+		kv     []interface{}
+		expect string
+	}{{
+		title:  "empty",
+		expect: ``,
+	}, {
+		title:  "pairs",
+		kv:     []interface{}{"user", user{Name: "foo"}, "count", 3},
+		expect: `user: {Name: "foo", Groups: nil}, count: 3`,
+	}, {
+		title:  "nil value",
+		kv:     []interface{}{"err", nil},
+		expect: `err: nil`,
+	}, {
+		title:  "odd number of arguments",
+		kv:     []interface{}{"count", 3, "lone"},
+		expect: `count: 3, !BADKEY: "lone"`,
+	}, {
+		title:  "non-string key",
+		kv:     []interface{}{42, "count", 3},
+		expect: `!BADKEY: 42, count: 3`,
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := Sprintkv(test.kv...)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+
+	t.Run("shared references", func(t *testing.T) {
+		type node struct {
+			Children []*node
+			Value    int
+		}
+
+		child := &node{Value: 2}
+		parent := &node{Children: []*node{child}, Value: 1}
+
+		const expect = `parent: {Children: []{r2={Children: nil, Value: 2}}, Value: 1}, child: r2`
+		s := Sprintkv("parent", parent, "child", child)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		const expect = `a, b:[]{1,2},c:3`
+		s := Printer{Compact: true}.Sprintkv("a, b", []int{1, 2}, "c", 3)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		const expect = `user: {
+	Name: "foo",
+	Groups: []{
+		"bar",
+		"baz",
+	},
+}
+count: 3`
+
+		defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+		var b bytes.Buffer
+		Fprintkvw(&b, "user", user{Name: "foo", Groups: []string{"bar", "baz"}}, "count", 3)
+		if s := b.String(); s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
@@ -38,6 +38,12 @@ type nodeRef struct {
 type pending struct {
 	values    map[uintptr]nodeRef
 	idCounter int
+	depth     int
+	shared    map[refKey]*sharedLabel
+
+	// tracking the wrapped errors that are not references, see checkPendingError:
+	errors      map[interface{}]nodeRef
+	unwrapDepth int
 }
 
 type node struct {
@@ -55,6 +61,11 @@ type str struct {
 	useRaw bool
 }
 
+// text is a part of a node that is part of the printed value, e.g. the digits of a number or the comment
+// showing the name of an enum value, but, unlike str, it doesn't need to be the only part of its node. The
+// compact option is not applied to it.
+type text string
+
 type wrapMode int
 
 const (
@@ -70,13 +81,20 @@ type wrapper struct {
 }
 
 type writer struct {
-	w   io.Writer
-	n   int
-	err error
+	w          io.Writer
+	n          int
+	err        error
+	singleLine bool
+	compact    bool
 }
 
 var stderr io.Writer = os.Stderr
 
+var (
+	compactSyntax    = strings.NewReplacer(", ", ",", ": ", ":")
+	escapeLineBreaks = strings.NewReplacer("\n", `\n`, "\r", `\r`)
+)
+
 func nodeOf(parts ...interface{}) node {
 	return node{parts: parts}
 }
@@ -107,6 +125,22 @@ func (w *writer) write(o interface{}) {
 	w.err = err
 }
 
+// writePart writes the parts of the nodes other than the nested nodes and wrappers. The compact option is
+// applied only to the syntax, which is represented by the plain string parts, while the values are
+// represented by str and text. The single-line option escapes the line breaks even in the text of the
+// built-in renderers.
+func (w *writer) writePart(p interface{}) {
+	if s, ok := p.(string); ok && w.compact {
+		p = compactSyntax.Replace(s)
+	}
+
+	if w.singleLine {
+		p = escapeLineBreaks.Replace(fmt.Sprint(p))
+	}
+
+	w.write(p)
+}
+
 func (w *writer) blankLine() {
 	w.write("\n")
 }
@@ -140,17 +174,101 @@ func config(name string, dflt int) int {
 	return v
 }
 
-func fprintValues(w io.Writer, o opts, v []interface{}) (int, error) {
-	tab := config("TABWIDTH", 8)
-	cols0 := config("LINEWIDTH", 80-tab)
-	cols1 := config("LINEWIDTH1", (cols0+tab)*3/2-tab)
-	sortMaps := config("MAPSORT", 1)
+type layout struct {
+	tab, cols0, cols1 int
+}
+
+// setup applies the configuration that affects the whole print call, from the environment and from the
+// printer options.
+func (pr *Printer) setup(o opts, v []interface{}) (opts, layout) {
+	conf := config
+	if pr.IgnoreEnvironment {
+		conf = func(_ string, dflt int) int { return dflt }
+	}
+
+	var l layout
+	l.tab = conf("TABWIDTH", 8)
+	l.cols0 = conf("LINEWIDTH", 80-l.tab)
+	if pr.LineWidth > 0 {
+		l.cols0 = pr.LineWidth
+	}
+
+	l.cols1 = conf("LINEWIDTH1", (l.cols0+l.tab)*3/2-l.tab)
+	sortMaps := conf("MAPSORT", 1)
 	if sortMaps == 0 {
 		o |= randomMaps
 	}
 
-	wr := &writer{w: w}
+	if pr.SingleLine {
+		o &^= wrap
+		pr.RawStrings = NeverRawStrings
+	}
+
+	if pr.TypeNames == AutoTypeNames && (o&types != 0 || o&allTypes != 0) {
+		pr.autoTypeNames = autoTypeNames(v)
+	}
+
+	if pr.SharedRefs {
+		pr.sharedRefs = sharedRefs(v)
+	}
+
+	return o, l
+}
+
+func (pr *Printer) newWriter(w io.Writer) *writer {
+	return &writer{w: w, singleLine: pr.SingleLine, compact: pr.Compact}
+}
+
+func (l layout) fprintNode(wr *writer, o opts, n node) {
+	if o&wrap != 0 {
+		n = nodeLen(l.tab, n)
+		n = wrapNode(l.tab, l.cols0, l.cols0, l.cols1, n)
+	}
+
+	fprint(wr, 0, n)
+}
+
+func newPending() *pending {
+	return &pending{
+		values: make(map[uintptr]nodeRef),
+		shared: make(map[refKey]*sharedLabel),
+		errors: make(map[interface{}]nodeRef),
+	}
+}
+
+func (pr Printer) fprintValues(w io.Writer, o opts, v []interface{}) (int, error) {
+	if pr.Select != "" {
+		return pr.fprintSelected(w, o, v)
+	}
+
+	o, l := pr.setup(o, v)
+	wr := pr.newWriter(w)
+
+	// with SharedRefs, the values share the reference tracking:
+	var shared *pending
+	if pr.SharedRefs {
+		shared = newPending()
+	}
+
+	// all the values are processed before printing them, because the labels of the shared references are
+	// printed only when a later value refers to them:
+	//
+	nodes := make([]node, len(v))
 	for i, vi := range v {
+		if vi == nil {
+			nodes[i] = nodeOf("nil")
+			continue
+		}
+
+		p := shared
+		if p == nil {
+			p = newPending()
+		}
+
+		nodes[i] = reflectValue(o, &pr, p, reflect.ValueOf(vi))
+	}
+
+	for i, n := range nodes {
 		if wr.err != nil {
 			return wr.n, wr.err
 		}
@@ -163,30 +281,18 @@ func fprintValues(w io.Writer, o opts, v []interface{}) (int, error) {
 			}
 		}
 
-		if vi == nil {
-			fprint(wr, 0, nodeOf("nil"))
-			continue
-		}
-
-		p := &pending{values: make(map[uintptr]nodeRef)}
-		n := reflectValue(o, p, reflect.ValueOf(vi))
-		if o&wrap != 0 {
-			n = nodeLen(tab, n)
-			n = wrapNode(tab, cols0, cols0, cols1, n)
-		}
-
-		fprint(wr, 0, n)
+		l.fprintNode(wr, o, n)
 	}
 
 	return wr.n, wr.err
 }
 
-func printValues(o opts, v []interface{}) (int, error) {
-	return fprintValues(stderr, o, v)
+func (pr Printer) printValues(o opts, v []interface{}) (int, error) {
+	return pr.fprintValues(stderr, o, v)
 }
 
-func printlnValues(o opts, v []interface{}) (int, error) {
-	n, err := fprintValues(stderr, o, v)
+func (pr Printer) printlnValues(o opts, v []interface{}) (int, error) {
+	n, err := pr.fprintValues(stderr, o, v)
 	if err != nil {
 		return n, err
 	}
@@ -195,154 +301,154 @@ func printlnValues(o opts, v []interface{}) (int, error) {
 	return n + nn, err
 }
 
-func sprintValues(o opts, v []interface{}) string {
+func (pr Printer) sprintValues(o opts, v []interface{}) string {
 	var b bytes.Buffer
-	fprintValues(&b, o, v)
+	pr.fprintValues(&b, o, v)
 	return b.String()
 }
 
 // Fprint prints the provided objects to the provided writer. When multiple objects are printed, they'll be
 // separated by a space.
 func Fprint(w io.Writer, v ...interface{}) (int, error) {
-	return fprintValues(w, none, v)
+	return Printer{}.Fprint(w, v...)
 }
 
 // Fprintw prints the provided objects to the provided writer, with wrapping (and indentation) where necessary.
 // When multiple objects are printed, they'll be separated by a newline.
 func Fprintw(w io.Writer, v ...interface{}) (int, error) {
-	return fprintValues(w, wrap, v)
+	return Printer{}.Fprintw(w, v...)
 }
 
 // Fprintt prints the provided objects to the provided writer with moderate type information. When multiple
 // objects are printed, they'll be separated by a space.
 func Fprintt(w io.Writer, v ...interface{}) (int, error) {
-	return fprintValues(w, types, v)
+	return Printer{}.Fprintt(w, v...)
 }
 
 // Fprintwt prints the provided objects to the provided writer, with wrapping (and indentation) where necessary,
 // and with moderate type information. When multiple objects are printed, they'll be separated by a newline.
 func Fprintwt(w io.Writer, v ...interface{}) (int, error) {
-	return fprintValues(w, wrap|types, v)
+	return Printer{}.Fprintwt(w, v...)
 }
 
 // Fprintv prints the provided objects to the provided writer with verbose type information. When multiple
 // objects are printed, they'll be separated by a space.
 func Fprintv(w io.Writer, v ...interface{}) (int, error) {
-	return fprintValues(w, allTypes, v)
+	return Printer{}.Fprintv(w, v...)
 }
 
 // Fprintwv prints the provided objects to the provided writer, with wrapping (and indentation) where necessary,
 // and with verbose type information. When multiple objects are printed, they'll be separated by a newline.
 func Fprintwv(w io.Writer, v ...interface{}) (int, error) {
-	return fprintValues(w, wrap|allTypes, v)
+	return Printer{}.Fprintwv(w, v...)
 }
 
 // Print prints the provided objects to stderr. When multiple objects are printed, they'll be separated by a
 // space.
 func Print(v ...interface{}) (int, error) {
-	return printValues(none, v)
+	return Printer{}.Print(v...)
 }
 
 // Printw prints the provided objects to stderr, with wrapping (and indentation) where necessary. When multiple
 // objects are printed, they'll be separated by a newline.
 func Printw(v ...interface{}) (int, error) {
-	return printValues(wrap, v)
+	return Printer{}.Printw(v...)
 }
 
 // Printt prints the provided objects to stderr with moderate type information. When multiple objects are
 // printed, they'll be separated by a space.
 func Printt(v ...interface{}) (int, error) {
-	return printValues(types, v)
+	return Printer{}.Printt(v...)
 }
 
 // Printwt prints the provided objects to stderr, with wrapping (and indentation) where necessary, and with
 // moderate type information. When multiple objects are printed, they'll be separated by a newline.
 func Printwt(v ...interface{}) (int, error) {
-	return printValues(wrap|types, v)
+	return Printer{}.Printwt(v...)
 }
 
 // Printv prints the provided objects to stderr with verbose type information. When multiple objects are
 // printed, they'll be separated by a space.
 func Printv(v ...interface{}) (int, error) {
-	return printValues(allTypes, v)
+	return Printer{}.Printv(v...)
 }
 
 // Printwv prints the provided objects to stderr, with wrapping (and indentation) where necessary, and with
 // verbose type information. When multiple objects are printed, they'll be separated by a newline.
 func Printwv(v ...interface{}) (int, error) {
-	return printValues(wrap|allTypes, v)
+	return Printer{}.Printwv(v...)
 }
 
 // Println prints the provided objects to stderr with a closing newline. When multiple objects are printed,
 // they'll be separated by a space.
 func Println(v ...interface{}) (int, error) {
-	return printlnValues(none, v)
+	return Printer{}.Println(v...)
 }
 
 // Printlnw prints the provided objects to stderr with a closing newline, with wrapping (and indentation) where
 // necessary. When multiple objects are printed, they'll be separated by a newline.
 func Printlnw(v ...interface{}) (int, error) {
-	return printlnValues(wrap, v)
+	return Printer{}.Printlnw(v...)
 }
 
 // Printlnt prints the provided objects to stderr with a closing newline, and with moderate type information. When
 // multiple objects are printed, they'll be separated by a space.
 func Printlnt(v ...interface{}) (int, error) {
-	return printlnValues(types, v)
+	return Printer{}.Printlnt(v...)
 }
 
 // Printlnwt prints the provided objects to stderr with a closing newline, with wrapping (and indentation) where
 // necessary, and with moderate type information. When multiple objects are printed, they'll be separated by a
 // newline.
 func Printlnwt(v ...interface{}) (int, error) {
-	return printlnValues(wrap|types, v)
+	return Printer{}.Printlnwt(v...)
 }
 
 // Printlnv prints the provided objects to stderr with a closing newline, and with verbose type information. When
 // multiple objects are printed, they'll be separated by a space.
 func Printlnv(v ...interface{}) (int, error) {
-	return printlnValues(allTypes, v)
+	return Printer{}.Printlnv(v...)
 }
 
 // Printlnwv prints the provided objects to stderr with a closing newline, with wrapping (and indentation) where
 // necessary, and with verbose type information. When multiple objects are printed, they'll be separated by a
 // newline.
 func Printlnwv(v ...interface{}) (int, error) {
-	return printlnValues(wrap|allTypes, v)
+	return Printer{}.Printlnwv(v...)
 }
 
 // Sprint returns the string representation of the Go objects. When multiple objects are provided, they'll be
 // seprated by a space.
 func Sprint(v ...interface{}) string {
-	return sprintValues(none, v)
+	return Printer{}.Sprint(v...)
 }
 
 // Sprintw returns the string representation of the Go objects, with wrapping (and indentation) where necessary.
 // When multiple objects are provided, they'll be seprated by a newline.
 func Sprintw(v ...interface{}) string {
-	return sprintValues(wrap, v)
+	return Printer{}.Sprintw(v...)
 }
 
 // Sprintt returns the string representation of the Go objects, with moderate type information. When multiple
 // objects are provided, they'll be seprated by a space.
 func Sprintt(v ...interface{}) string {
-	return sprintValues(types, v)
+	return Printer{}.Sprintt(v...)
 }
 
 // Sprintwt returns the string representation of the Go objects, with wrapping (and indentation) where necessary,
 // and with moderate type information. When multiple objects are provided, they'll be seprated by a newline.
 func Sprintwt(v ...interface{}) string {
-	return sprintValues(wrap|types, v)
+	return Printer{}.Sprintwt(v...)
 }
 
 // Sprintv returns the string representation of the Go objects, with verbose type information. When multiple
 // objects are provided, they'll be seprated by a space.
 func Sprintv(v ...interface{}) string {
-	return sprintValues(allTypes, v)
+	return Printer{}.Sprintv(v...)
 }
 
 // Sprintwv returns the string representation of the Go objects, with wrapping (and indentation) where necessary,
 // and with verbose type information. When multiple objects are provided, they'll be seprated by a newline.
 func Sprintwv(v ...interface{}) string {
-	return sprintValues(wrap|allTypes, v)
+	return Printer{}.Sprintwv(v...)
 }
@@ -0,0 +1,105 @@
+package notationtest
+
+import "strings"
+
+type diffOp int
+
+const (
+	same diffOp = iota
+	removed
+	added
+)
+
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// diffLines calculates the line diff between a and b, based on their longest common subsequence.
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var d []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			d = append(d, diffLine{op: same, text: a[i]})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			d = append(d, diffLine{op: removed, text: a[i]})
+			i++
+		default:
+			d = append(d, diffLine{op: added, text: b[j]})
+			j++
+		}
+	}
+
+	return d
+}
+
+// diff returns the line diff of want and got, prefixing the removed lines with "-", the added lines with "+",
+// and the unchanged lines with a space. When context is not negative, only the specified number of the
+// unchanged lines are kept around the changes, and the omitted lines are marked by "...".
+func diff(want, got string, context int) string {
+	d := diffLines(strings.Split(want, "\n"), strings.Split(got, "\n"))
+	keep := make([]bool, len(d))
+	for i, l := range d {
+		switch {
+		case context < 0:
+			keep[i] = true
+		case l.op != same:
+			for j := max(0, i-context); j <= i+context && j < len(d); j++ {
+				keep[j] = true
+			}
+		}
+	}
+
+	var b strings.Builder
+	var skipped bool
+	for i, l := range d {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+
+		if skipped {
+			b.WriteString("  ...\n")
+			skipped = false
+		}
+
+		switch l.op {
+		case removed:
+			b.WriteString("- ")
+		case added:
+			b.WriteString("+ ")
+		default:
+			b.WriteString("  ")
+		}
+
+		b.WriteString(l.text)
+		b.WriteString("\n")
+	}
+
+	if skipped && b.Len() > 0 {
+		b.WriteString("  ...\n")
+	}
+
+	return b.String()
+}
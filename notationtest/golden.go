@@ -0,0 +1,98 @@
+package notationtest
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aryszka/notation"
+)
+
+// the flag has a package specific name, because many test packages define their own -update flag:
+var update = flag.Bool("notationtest.update", false, "update the golden files of notationtest.Golden")
+
+// updateGolden tells whether the golden files need to be written. Besides its own flag, it accepts the
+// -update flag, when the test package defines one. This can be checked only when running the tests, because
+// the flags of the test package are defined after the ones of its dependencies.
+func updateGolden() bool {
+	if *update {
+		return true
+	}
+
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+
+	g, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+
+	u, ok := g.Get().(bool)
+	return ok && u
+}
+
+// the directory of the golden files, relative to the package of the test:
+var goldenDir = "testdata"
+
+// the output of the printer used for the golden files is deterministic: it is independent of the
+// environment, it doesn't print addresses, the maps are always sorted, and the references are numbered in the
+// order of traversal:
+var goldenPrinter = notation.Printer{IgnoreEnvironment: true}
+
+func goldenPath(t testing.TB) string {
+	return filepath.Join(goldenDir, filepath.FromSlash(t.Name())+".golden")
+}
+
+// Golden compares the value, printed with notation.Sprintwt, with the content of the golden file of the
+// test: testdata/<TestName>.golden. When the test is run with the -notationtest.update flag, or with the
+// -update flag defined by the test package, it writes the golden file instead. If the golden file is
+// missing, or its content is different, it fails the test with t.Errorf, showing a line diff. It returns
+// whether the value matched the golden file.
+func Golden(t testing.TB, v interface{}) bool {
+	t.Helper()
+	path := goldenPath(t)
+	got := goldenPrinter.Sprintwt(v) + "\n"
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("failed to create the golden file directory: %v", err)
+			return false
+		}
+
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Errorf("failed to write the golden file: %v", err)
+			return false
+		}
+
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("golden file not found: %s; run the test with -notationtest.update to create it", path)
+		return false
+	}
+
+	if err != nil {
+		t.Errorf("failed to read the golden file: %v", err)
+		return false
+	}
+
+	if string(want) == got {
+		return true
+	}
+
+	t.Errorf(
+		"value doesn't match the golden file: %s; run the test with -notationtest.update to update it\n"+
+			"diff (-golden +got):\n%s",
+		path,
+		diff(strings.TrimSuffix(string(want), "\n"), strings.TrimSuffix(got, "\n"), diffContext),
+	)
+
+	return false
+}
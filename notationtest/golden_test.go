@@ -0,0 +1,118 @@
+package notationtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type config struct {
+	Name    string
+	Ports   []int
+	Labels  map[string]string
+	Backend *config
+}
+
+var goldenConfig = config{
+	Name:   "frontend",
+	Ports:  []int{80, 443},
+	Labels: map[string]string{"tier": "web", "env": "prod"},
+	Backend: &config{
+		Name:  "backend",
+		Ports: []int{8080},
+	},
+}
+
+// many test packages define their own -update flag, and importing notationtest must not conflict with it:
+var testUpdate = flag.Bool("update", false, "update the golden files of the test package")
+
+func withGoldenDir(t *testing.T) func() {
+	dir := goldenDir
+	goldenDir = t.TempDir()
+	return func() { goldenDir = dir }
+}
+
+func withUpdate(u bool) func() {
+	current := *update
+	*update = u
+	return func() { *update = current }
+}
+
+func withTestUpdate(u bool) func() {
+	current := *testUpdate
+	*testUpdate = u
+	return func() { *testUpdate = current }
+}
+
+func TestGolden(t *testing.T) {
+	// compares with the checked in testdata/TestGolden/config.golden:
+	t.Run("config", func(t *testing.T) {
+		Golden(t, goldenConfig)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		defer withGoldenDir(t)()
+		defer withUpdate(false)()
+		defer withTestUpdate(false)()
+		r := &recorder{TB: t}
+		if Golden(r, 42) || len(r.failures) != 1 || !strings.Contains(r.failures[0], "golden file not found") {
+			t.Fatalf("expected: missing golden file, got: %v", r.failures)
+		}
+	})
+
+	t.Run("update and compare", func(t *testing.T) {
+		defer withGoldenDir(t)()
+		r := &recorder{TB: t, name: "TestFoo/bar_baz"}
+		restore := withUpdate(true)
+		ok := Golden(r, goldenConfig)
+		restore()
+		if !ok || len(r.failures) != 0 {
+			t.Fatalf("expected: updated, got: %v", r.failures)
+		}
+
+		if _, err := os.Stat(filepath.Join(goldenDir, "TestFoo", "bar_baz.golden")); err != nil {
+			t.Fatal(err)
+		}
+
+		if !Golden(r, goldenConfig) || len(r.failures) != 0 {
+			t.Fatalf("expected: match, got: %v", r.failures)
+		}
+	})
+
+	t.Run("update with the flag of the test package", func(t *testing.T) {
+		defer withGoldenDir(t)()
+		r := &recorder{TB: t}
+		restore := withTestUpdate(true)
+		ok := Golden(r, goldenConfig)
+		restore()
+		if !ok || len(r.failures) != 0 {
+			t.Fatalf("expected: updated, got: %v", r.failures)
+		}
+
+		if _, err := os.Stat(goldenPath(r)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		defer withGoldenDir(t)()
+		defer withUpdate(false)()
+		defer withTestUpdate(false)()
+		r := &recorder{TB: t}
+		restore := withUpdate(true)
+		Golden(r, goldenConfig)
+		restore()
+
+		changed := goldenConfig
+		changed.Ports = []int{80, 8443}
+		if Golden(r, changed) || len(r.failures) != 1 {
+			t.Fatalf("expected: mismatch, got: %v", r.failures)
+		}
+
+		if !strings.Contains(r.failures[0], "- \tPorts: []{80, 443},\n+ \tPorts: []{80, 8443},\n") {
+			t.Fatalf("expected: diff, got: %s", r.failures[0])
+		}
+	})
+}
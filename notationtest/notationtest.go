@@ -0,0 +1,119 @@
+/*
+Package notationtest provides test helpers that compare Go objects, and, on failure, report the difference
+using the notation package.
+*/
+package notationtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aryszka/notation"
+)
+
+// the number of the unchanged lines shown around the differences, when not running in verbose mode:
+const diffContext = 3
+
+// the values are wrapped at every level, to get a structural diff:
+var printer = notation.Printer{LineWidth: 1}
+
+func sprint(v interface{}) string {
+	return printer.Sprintwt(v)
+}
+
+// report formats the failure message. In verbose mode, it includes the full representation of both values.
+func report(title, wantLabel string, want, got interface{}) string {
+	ws, gs := sprint(want), sprint(got)
+	var b strings.Builder
+	b.WriteString(title)
+	b.WriteString("\n")
+	if testing.Verbose() {
+		fmt.Fprintf(&b, "got:\n%s\n%s:\n%s\n", gs, wantLabel, ws)
+		fmt.Fprintf(&b, "diff (-%s +got):\n%s", wantLabel, diff(ws, gs, -1))
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "diff (-%s +got):\n%s", wantLabel, diff(ws, gs, diffContext))
+	return b.String()
+}
+
+// Equal checks whether got and want are deeply equal, as reported by reflect.DeepEqual, which also handles
+// cyclic references. If they are not, it fails the test with t.Errorf, showing the difference of the values
+// printed with notation.Sprintwt, wrapped at every level. When running the tests in verbose mode, it shows
+// the full values, too. It returns whether the values are equal.
+func Equal(t testing.TB, got, want interface{}) bool {
+	t.Helper()
+	if reflect.DeepEqual(got, want) {
+		return true
+	}
+
+	t.Errorf("%s", report("values are not equal", "want", want, got))
+	return false
+}
+
+// NotEqual checks whether got and notWant are different. If they are deeply equal, it fails the test with
+// t.Errorf, showing the value. It returns whether the values are different.
+func NotEqual(t testing.TB, got, notWant interface{}) bool {
+	t.Helper()
+	if !reflect.DeepEqual(got, notWant) {
+		return true
+	}
+
+	t.Errorf("values are equal:\n%s", sprint(got))
+	return false
+}
+
+func contains(container, item interface{}) (bool, error) {
+	if s, ok := container.(string); ok {
+		sub, ok := item.(string)
+		if !ok {
+			return false, fmt.Errorf("cannot check whether a string contains %s", notation.Sprintt(item))
+		}
+
+		return strings.Contains(s, sub), nil
+	}
+
+	r := reflect.ValueOf(container)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < r.Len(); i++ {
+			if reflect.DeepEqual(r.Index(i).Interface(), item) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case reflect.Map:
+		for _, key := range r.MapKeys() {
+			if reflect.DeepEqual(key.Interface(), item) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported container: %s", notation.Sprintt(container))
+	}
+}
+
+// Contains checks whether the container contains the item. The container can be a string, in which case the
+// item needs to be a substring, a slice or an array, in which case one of the elements needs to be deeply
+// equal to the item, or a map, in which case one of the keys needs to be deeply equal to the item. If the
+// container doesn't contain the item, it fails the test with t.Errorf, showing both. It returns whether the
+// container contains the item.
+func Contains(t testing.TB, container, item interface{}) bool {
+	t.Helper()
+	ok, err := contains(container, item)
+	if err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+
+	if !ok {
+		t.Errorf("item not found:\n%s\ncontainer:\n%s", sprint(item), sprint(container))
+	}
+
+	return ok
+}
@@ -0,0 +1,161 @@
+package notationtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type recorder struct {
+	testing.TB
+	name     string
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Name() string {
+	if r.name != "" {
+		return r.name
+	}
+
+	return r.TB.Name()
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+type item struct {
+	Name   string
+	Values []int
+}
+
+func TestDiff(t *testing.T) {
+	for _, test := range []struct {
+		title     string
+		want, got string
+		context   int
+		expect    string
+	}{{
+		title:  "equal",
+		want:   "foo\nbar",
+		got:    "foo\nbar",
+		expect: "",
+	}, {
+		title:   "full",
+		want:    "foo\nbar\nbaz",
+		got:     "foo\nqux\nbaz",
+		context: -1,
+		expect:  "  foo\n- bar\n+ qux\n  baz\n",
+	}, {
+		title:   "context",
+		want:    "1\n2\n3\n4\n5\n6\n7",
+		got:     "1\n2\n3\n4\nfoo\n6\n7",
+		context: 1,
+		expect:  "  ...\n  4\n- 5\n+ foo\n  6\n  ...\n",
+	}, {
+		title:   "added and removed lines",
+		want:    "foo\nbar",
+		got:     "bar\nbaz",
+		context: 0,
+		expect:  "- foo\n  ...\n+ baz\n",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := diff(test.want, test.got, test.context)
+			if s != test.expect {
+				t.Fatalf("expected: %q, got: %q", test.expect, s)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		r := &recorder{TB: t}
+		if !Equal(r, item{Name: "foo"}, item{Name: "foo"}) || len(r.failures) != 0 {
+			t.Fatalf("expected: equal, got: %v", r.failures)
+		}
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+		type node struct{ Next *node }
+		a, b := &node{}, &node{}
+		a.Next, b.Next = a, b
+		r := &recorder{TB: t}
+		if !Equal(r, a, b) {
+			t.Fatalf("expected: equal, got: %v", r.failures)
+		}
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		r := &recorder{TB: t}
+		if Equal(r, item{Name: "foo", Values: []int{1, 2}}, item{Name: "bar", Values: []int{1, 2}}) {
+			t.Fatal("expected: not equal")
+		}
+
+		if len(r.failures) != 1 ||
+			!strings.Contains(r.failures[0], `- 	Name: "bar",`) ||
+			!strings.Contains(r.failures[0], `+ 	Name: "foo",`) {
+			t.Fatalf("expected: diff, got: %v", r.failures)
+		}
+	})
+}
+
+func TestNotEqual(t *testing.T) {
+	r := &recorder{TB: t}
+	if !NotEqual(r, 1, 2) || len(r.failures) != 0 {
+		t.Fatalf("expected: not equal, got: %v", r.failures)
+	}
+
+	if NotEqual(r, item{Name: "foo"}, item{Name: "foo"}) || len(r.failures) != 1 {
+		t.Fatalf("expected: equal, got: %v", r.failures)
+	}
+}
+
+func TestContains(t *testing.T) {
+	for _, test := range []struct {
+		title           string
+		container, item interface{}
+		expect          bool
+		failure         string
+	}{{
+		title:     "substring",
+		container: "foobar",
+		item:      "oba",
+		expect:    true,
+	}, {
+		title:     "slice",
+		container: []item{{Name: "foo"}, {Name: "bar"}},
+		item:      item{Name: "bar"},
+		expect:    true,
+	}, {
+		title:     "map key",
+		container: map[string]int{"foo": 1},
+		item:      "foo",
+		expect:    true,
+	}, {
+		title:     "not found",
+		container: []int{1, 2, 3},
+		item:      4,
+		failure:   "item not found",
+	}, {
+		title:     "unsupported",
+		container: 42,
+		item:      4,
+		failure:   "unsupported container: 42",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ok := Contains(r, test.container, test.item)
+			if ok != test.expect {
+				t.Fatalf("expected: %t, got: %t", test.expect, ok)
+			}
+
+			if test.expect && len(r.failures) != 0 ||
+				!test.expect && (len(r.failures) != 1 || !strings.Contains(r.failures[0], test.failure)) {
+				t.Fatalf("expected: %s, got: %v", test.failure, r.failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package notation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// IntegerBase controls the base used for printing the integers.
+type IntegerBase int
+
+const (
+
+	// DefaultBase prints the uintptr values as hexadecimal numbers with the 0x prefix, and all other integers
+	// as decimal numbers.
+	DefaultBase IntegerBase = iota
+
+	// DecimalBase prints all the integers as decimal numbers.
+	DecimalBase
+
+	// HexBase prints all the integers as hexadecimal numbers with the 0x prefix.
+	HexBase
+
+	// OctalBase prints all the integers as octal numbers with the 0o prefix.
+	OctalBase
+
+	// BinaryBase prints all the integers as binary numbers with the 0b prefix.
+	BinaryBase
+)
+
+// FloatFormat controls how the floating point numbers are printed.
+type FloatFormat int
+
+const (
+
+	// ShortestFloat prints the floats the same way as fmt.Print, with the shortest representation that
+	// identifies the value, and with an exponent only for large or small exponents. This is the default.
+	ShortestFloat FloatFormat = iota
+
+	// DecimalFloat prints the floats without an exponent, e.g. 1000000.5.
+	DecimalFloat
+
+	// ExponentFloat prints the floats always with an exponent, e.g. 1.0000005e+06.
+	ExponentFloat
+)
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func stringerText(s fmt.Stringer) (text string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	return s.String(), true
+}
+
+// groupDigits inserts an underscore between every n digits of the first sequence of digits in s, counting
+// from the right. The sign and the base prefix are skipped.
+func groupDigits(s string, n int) string {
+	var prefix string
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		prefix, s = s[:1], s[1:]
+	}
+
+	digitChars := "0123456789"
+	if len(s) > 1 && s[0] == '0' && strings.ContainsRune("xob", rune(s[1])) {
+		if s[1] == 'x' {
+			digitChars += "abcdef"
+		}
+
+		prefix, s = prefix+s[:2], s[2:]
+	}
+
+	end := strings.IndexFunc(s, func(c rune) bool {
+		return !strings.ContainsRune(digitChars, c)
+	})
+
+	if end < 0 {
+		end = len(s)
+	}
+
+	digits, rest := s[:end], s[end:]
+	var b strings.Builder
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%n == 0 {
+			b.WriteByte('_')
+		}
+
+		b.WriteRune(c)
+	}
+
+	return prefix + b.String() + rest
+}
+
+func (pr *Printer) formatInt(r reflect.Value) string {
+	var (
+		u   uint64
+		neg bool
+	)
+
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := r.Int()
+		neg = i < 0
+		u = uint64(i)
+		if neg {
+			u = -u
+		}
+	default:
+		u = r.Uint()
+	}
+
+	base := pr.IntegerBase
+	if base == DefaultBase {
+		base = DecimalBase
+		if r.Kind() == reflect.Uintptr {
+			base = HexBase
+		}
+	}
+
+	var s string
+	group := 3
+	switch base {
+	case HexBase:
+		s = "0x" + strconv.FormatUint(u, 16)
+		group = 4
+	case OctalBase:
+		s = "0o" + strconv.FormatUint(u, 8)
+	case BinaryBase:
+		s = "0b" + strconv.FormatUint(u, 2)
+		group = 4
+	default:
+		s = strconv.FormatUint(u, 10)
+	}
+
+	if pr.DigitGrouping {
+		s = groupDigits(s, group)
+	}
+
+	if neg {
+		s = "-" + s
+	}
+
+	return s + pr.enumName(r)
+}
+
+// enumName returns the result of the String method of the named integer types in a comment, when enabled.
+func (pr *Printer) enumName(r reflect.Value) string {
+	if !pr.EnumNames || r.Type().PkgPath() == "" || !r.Type().Implements(stringerType) || !r.CanInterface() {
+		return ""
+	}
+
+	s, ok := stringerText(r.Interface().(fmt.Stringer))
+	if !ok {
+		return ""
+	}
+
+	return " /* " + s + " */"
+}
+
+func (pr *Printer) floatFormat() (byte, int) {
+	prec := -1
+	if pr.FloatPrecision > 0 {
+		prec = pr.FloatPrecision
+	}
+
+	switch pr.FloatFormat {
+	case DecimalFloat:
+		return 'f', prec
+	case ExponentFloat:
+		return 'e', prec
+	default:
+		return 'g', prec
+	}
+}
+
+func (pr *Printer) formatFloat(r reflect.Value) string {
+	f := r.Float()
+	switch {
+	case pr.GoSpecialFloats && math.IsNaN(f):
+		return "math.NaN()"
+	case pr.GoSpecialFloats && math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case pr.GoSpecialFloats && math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+
+	// the values are formatted as float64, including the float32 values, the same way as fmt prints the
+	// result of reflect.Value.Float:
+	//
+	var s string
+	if pr.FloatFormat == ShortestFloat && pr.FloatPrecision <= 0 {
+		s = fmt.Sprint(f)
+	} else {
+		fc, prec := pr.floatFormat()
+		s = strconv.FormatFloat(f, fc, prec, 64)
+	}
+
+	if pr.DigitGrouping {
+		s = groupDigits(s, 3)
+	}
+
+	return s
+}
+
+func (pr *Printer) formatComplex(r reflect.Value) string {
+	if pr.FloatFormat == ShortestFloat && pr.FloatPrecision <= 0 {
+		return fmt.Sprint(r.Complex())
+	}
+
+	fc, prec := pr.floatFormat()
+	return strconv.FormatComplex(r.Complex(), fc, prec, 128)
+}
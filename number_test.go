@@ -0,0 +1,112 @@
+package notation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNumberFormat(t *testing.T) {
+	for _, test := range []struct {
+		title   string
+		printer Printer
+		value   interface{}
+		expect  string
+	}{{
+		title:  "uintptr is hexa by default",
+		value:  []interface{}{uintptr(0xc000012345), uint(42), -42},
+		expect: `[]{0xc000012345, 42, -42}`,
+	}, {
+		title:   "hexa",
+		printer: Printer{IntegerBase: HexBase},
+		value:   []interface{}{uint32(0xdeadbeef), -255, 0},
+		expect:  `[]{0xdeadbeef, -0xff, 0x0}`,
+	}, {
+		title:   "octal",
+		printer: Printer{IntegerBase: OctalBase},
+		value:   0755,
+		expect:  `0o755`,
+	}, {
+		title:   "binary",
+		printer: Printer{IntegerBase: BinaryBase},
+		value:   uint8(5),
+		expect:  `0b101`,
+	}, {
+		title:   "decimal uintptr",
+		printer: Printer{IntegerBase: DecimalBase},
+		value:   uintptr(255),
+		expect:  `255`,
+	}, {
+		title:   "min int",
+		printer: Printer{IntegerBase: HexBase},
+		value:   math.MinInt64,
+		expect:  `-0x8000000000000000`,
+	}, {
+		title:   "digit grouping",
+		printer: Printer{DigitGrouping: true},
+		value:   []interface{}{1000000, -1234, 123, 12345.678},
+		expect:  `[]{1_000_000, -1_234, 123, 12_345.678}`,
+	}, {
+		title:   "digit grouping, hexa",
+		printer: Printer{IntegerBase: HexBase, DigitGrouping: true},
+		value:   uint32(0xdeadbeef),
+		expect:  `0xdead_beef`,
+	}, {
+		title:   "digit grouping, exponent",
+		printer: Printer{DigitGrouping: true},
+		value:   1e21,
+		expect:  `1e+21`,
+	}, {
+		title:   "decimal float",
+		printer: Printer{FloatFormat: DecimalFloat},
+		value:   []float64{1e6, 0.1},
+		expect:  `[]{1000000, 0.1}`,
+	}, {
+		title:   "exponent float with precision",
+		printer: Printer{FloatFormat: ExponentFloat, FloatPrecision: 3},
+		value:   1234.5678,
+		expect:  `1.235e+03`,
+	}, {
+		title:   "decimal float with precision",
+		printer: Printer{FloatFormat: DecimalFloat, FloatPrecision: 2},
+		value:   []interface{}{math.Pi, complex(1, 2)},
+		expect:  `[]{3.14, 1.00+2.00i}`,
+	}, {
+		title:  "special floats",
+		value:  []float64{math.NaN(), math.Inf(1), math.Inf(-1)},
+		expect: `[]{NaN, +Inf, -Inf}`,
+	}, {
+		title:   "special floats, Go syntax",
+		printer: Printer{GoSpecialFloats: true},
+		value:   []float64{math.NaN(), math.Inf(1), math.Inf(-1)},
+		expect:  `[]{math.NaN(), math.Inf(1), math.Inf(-1)}`,
+	}, {
+		title:   "enum names",
+		printer: Printer{EnumNames: true},
+		value:   struct{ Day time.Weekday }{time.Wednesday},
+		expect:  `{Day: 3 /* Wednesday */}`,
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.printer.Sprint(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+
+	t.Run("enum names, with types", func(t *testing.T) {
+		const expect = `Weekday(3 /* Wednesday */)`
+		s := Printer{EnumNames: true}.Sprintt(time.Wednesday)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("special floats, with types", func(t *testing.T) {
+		const expect = `float32(math.NaN())`
+		s := Printer{GoSpecialFloats: true}.Sprintt(float32(math.NaN()))
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package notation
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+)
+
+// PathStepKind tells how a step of a Path gets from a value to a nested value.
+type PathStepKind int
+
+const (
+
+	// FieldStep selects a struct field.
+	FieldStep PathStepKind = iota
+
+	// IndexStep selects an item of a slice or an array.
+	IndexStep
+
+	// KeyStep selects the value of a map entry.
+	KeyStep
+
+	// DerefStep selects the value referenced by a pointer.
+	DerefStep
+)
+
+// PathStep is a single step of a Path. Depending on the kind, either the field name, the index or the map
+// key is set.
+type PathStep struct {
+	Kind  PathStepKind
+	Name  string
+	Index int
+	Key   reflect.Value
+}
+
+// Path describes where a value was found, starting from the root value passed in to Walk. The root value
+// has an empty path. The interfaces don't have a corresponding step.
+type Path []PathStep
+
+func fieldPath(path, name string) string {
+	return path + "." + name
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+func keyPath(path string, key reflect.Value) string {
+	return path + "[" + sprintValue(key) + "]"
+}
+
+// sprintValue prints a reflect.Value, also when it cannot be converted to an interface, e.g. because it was
+// obtained from an unexported field.
+func sprintValue(r reflect.Value) string {
+	var b bytes.Buffer
+	fprint(&writer{w: &b}, 0, reflectValue(none, &Printer{}, newPending(), r))
+	return b.String()
+}
+
+// String returns the path in the same format as the paths reported by Equal, e.g. .Items[1]["key"]. The
+// dereferencing steps are omitted.
+func (p Path) String() string {
+	var s string
+	for _, step := range p {
+		switch step.Kind {
+		case FieldStep:
+			s = fieldPath(s, step.Name)
+		case IndexStep:
+			s = indexPath(s, step.Index)
+		case KeyStep:
+			s = keyPath(s, step.Key)
+		}
+	}
+
+	return s
+}
+
+// append always copies the path, so that the paths passed to the visitor functions can be retained.
+func (p Path) append(step PathStep) Path {
+	return append(p[:len(p):len(p)], step)
+}
@@ -0,0 +1,333 @@
+package notation
+
+import (
+	"io"
+	"reflect"
+)
+
+// Printer can be used to print Go objects with custom options. The zero value of a Printer prints the objects
+// the same way as the package level functions.
+type Printer struct {
+
+	// OmitZero, when set, skips the struct fields whose value is the zero value of their type, as reported by
+	// reflect.Value.IsZero. It is applied recursively, to the nested values, too.
+	OmitZero bool
+
+	// OmitZeroMapEntries, when set, skips the map entries whose value is the zero value of its type.
+	OmitZeroMapEntries bool
+
+	// ExportedOnly, when set, skips the unexported struct fields. The number of the skipped fields is shown in
+	// a comment at the end of the struct.
+	ExportedOnly bool
+
+	// UnexportedPackages, when set, restricts printing the unexported struct fields to the listed packages,
+	// identified by their import path. The unexported fields of the types from other packages are skipped
+	// the same way as with ExportedOnly.
+	UnexportedPackages []string
+
+	// FlattenEmbedded, when set, prints the fields of the embedded structs as if they were the fields of the
+	// embedding struct, following the same rules as Go uses to resolve the promoted fields. Shadowed and
+	// ambiguous fields are not printed.
+	FlattenEmbedded bool
+
+	// TypeNames controls how the type names are printed when printing with type information. By default, the
+	// type names are printed without the package.
+	TypeNames TypeNameMode
+
+	// RawBuiltins disables the selected built-in renderers, printing the values of the affected types based on
+	// their internal structure, like any other Go object. By default, all the built-in renderers are enabled.
+	RawBuiltins Builtins
+
+	// FuncNames, when set, prints the fully qualified name and the source location of the function values in
+	// a comment, e.g. func() /* main.handleLogin at server.go:42 */. Closures are labeled by their enclosing
+	// function, e.g. func1 of main.handleLogin.
+	FuncNames bool
+
+	// ChanState, when set, prints the number of the buffered items and the capacity of the channels, e.g.
+	// chan int{len: 3, cap: 10}.
+	ChanState bool
+
+	// UnsafeChanPeek, when set, prints, in addition to ChanState, whether the channels are closed, and the
+	// buffered items without receiving them. It reads the internal state of the channels without
+	// synchronizing with the senders and the receivers, relying on the internal layout of the channels in
+	// the Go runtime. The printed state may be inconsistent, or the program may crash, when the channels are
+	// in use concurrently. It is meant only for debugging, e.g. for finding the cause of a stalled pipeline.
+	UnsafeChanPeek bool
+
+	// Bytes controls how the byte slices and the byte arrays are printed. By default, they are printed as
+	// hexadecimal words. It can be overridden for individual struct fields with the field tag, e.g.
+	// `notation:"bytes=hexdump"`, where the possible values are hex, hexdump, string, base64 and auto.
+	Bytes BytesMode
+
+	// IntegerBase controls the base of the printed integers. By default, the uintptr values are printed as
+	// hexadecimal numbers, and all other integers as decimal numbers.
+	IntegerBase IntegerBase
+
+	// DigitGrouping, when set, separates the digits of the numbers with underscores, the same way as it is
+	// allowed in the Go number literals, e.g. 1_000_000 or 0xdead_beef.
+	DigitGrouping bool
+
+	// FloatFormat controls whether the floating point numbers are printed with an exponent.
+	FloatFormat FloatFormat
+
+	// FloatPrecision sets the number of the digits printed after the decimal point, or, with ShortestFloat,
+	// the total number of the significant digits. When zero, the shortest representation is used that
+	// identifies the value.
+	FloatPrecision int
+
+	// GoSpecialFloats, when set, prints the NaN and infinite float values as Go expressions, e.g. math.NaN()
+	// or math.Inf(-1), instead of NaN and -Inf.
+	GoSpecialFloats bool
+
+	// EnumNames, when set, prints the result of the String method of the named integer types in a comment,
+	// e.g. Weekday(3 /* Wednesday */).
+	EnumNames bool
+
+	// MaxStringLen, when set, truncates the strings longer than the specified number of bytes, and indicates
+	// the number of the omitted bytes, e.g. "The quick brown"...(+28 bytes).
+	MaxStringLen int
+
+	// ASCIIStrings, when set, escapes all the non-ASCII characters in the strings, the same way as
+	// strconv.QuoteToASCII.
+	ASCIIStrings bool
+
+	// RawStrings controls when the strings are printed as raw string literals. By default, only the
+	// wrapped strings containing newlines are printed as raw strings.
+	RawStrings RawStrings
+
+	// StringLength, when set, prints the length of the strings in bytes in a comment, and, when different,
+	// also the number of the runes, e.g. "héllo" /* len: 6, runes: 5 */.
+	StringLength bool
+
+	// SingleLine, when set, guarantees that every print call produces a single line, e.g. for structured
+	// logging. The values are never wrapped, not even with the w variants, and when multiple values are
+	// printed, they are separated by a space. The strings are always quoted, and the line breaks in the
+	// output of the built-in renderers are escaped.
+	SingleLine bool
+
+	// Compact, when set, omits the spaces after the colons and the commas, e.g. {foo:1,bar:2}.
+	Compact bool
+
+	// LineWidth, when set, overrides the preferred maximum width of the lines used when wrapping. By default,
+	// it is 72 columns, or it can be set with the LINEWIDTH environment variable.
+	LineWidth int
+
+	// MaxDepth, when set, limits the depth of the printed nested structs, lists and maps. The values below
+	// the limit are elided, e.g. {foo: {...}}. The pointers and interfaces don't count as a level.
+	MaxDepth int
+
+	// Redact lists the names of the struct fields and the string map keys whose values must not be printed,
+	// e.g. Password. Their values are replaced by a comment: {User: "foo", Password: /* redacted */}.
+	Redact []string
+
+	// SharedRefs, when set, detects the pointers and maps that are referenced from multiple places, within
+	// a single value or across the values printed by the same call. Their first occurrence is labeled, and
+	// the further occurrences are printed as references to it, e.g. for Println(parent, child), where the
+	// child is reachable from the parent: {children: []{r0={name: "child"}}} r0. The label is printed only
+	// when a further occurrence is printed, too, and not, e.g., when it is below MaxDepth.
+	SharedRefs bool
+
+	// IgnoreEnvironment, when set, ignores the TABWIDTH, LINEWIDTH, LINEWIDTH1 and MAPSORT environment
+	// variables, and uses the defaults instead. It can be used when the output needs to be the same in every
+	// environment, e.g. in golden files.
+	IgnoreEnvironment bool
+
+	// Select, when set, prints only the parts of the values that match the path selector, each labeled with
+	// its path, e.g. .driveTrain.brakes[1]: {pads: 2}. See SprintAt for the selector syntax. When multiple
+	// parts match, they are separated by a comma, or, when wrapping, by a newline. The struct fields and the
+	// map entries that the other options hide or omit are not matched.
+	Select string
+
+	// collected during a single print call:
+	autoTypeNames map[string]TypeNameMode
+	sharedRefs    map[refKey]bool
+}
+
+var (
+
+	// Friendly is the preset used by the package level functions. It prints the values of the well known
+	// standard library types with the built-in renderers, e.g. time.Time, *big.Int or net.IP.
+	Friendly = Printer{}
+
+	// Raw is a preset that prints every value based on its internal structure, with all the built-in
+	// renderers disabled.
+	Raw = Printer{RawBuiltins: AllBuiltins}
+)
+
+const redactedText = "/* redacted */"
+
+var redactedValue = nodeOf(redactedText)
+
+func (pr *Printer) redacted(name string) bool {
+	for _, r := range pr.Redact {
+		if r == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (pr *Printer) hideUnexported(f reflect.StructField) bool {
+	if f.PkgPath == "" {
+		return false
+	}
+
+	if !pr.ExportedOnly && len(pr.UnexportedPackages) == 0 {
+		return false
+	}
+
+	for _, pkg := range pr.UnexportedPackages {
+		if f.PkgPath == pkg {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Fprint prints the provided objects to the provided writer. When multiple objects are printed, they'll be
+// separated by a space.
+func (pr Printer) Fprint(w io.Writer, v ...interface{}) (int, error) {
+	return pr.fprintValues(w, none, v)
+}
+
+// Fprintw prints the provided objects to the provided writer, with wrapping (and indentation) where necessary.
+// When multiple objects are printed, they'll be separated by a newline.
+func (pr Printer) Fprintw(w io.Writer, v ...interface{}) (int, error) {
+	return pr.fprintValues(w, wrap, v)
+}
+
+// Fprintt prints the provided objects to the provided writer with moderate type information. When multiple
+// objects are printed, they'll be separated by a space.
+func (pr Printer) Fprintt(w io.Writer, v ...interface{}) (int, error) {
+	return pr.fprintValues(w, types, v)
+}
+
+// Fprintwt prints the provided objects to the provided writer, with wrapping (and indentation) where necessary,
+// and with moderate type information. When multiple objects are printed, they'll be separated by a newline.
+func (pr Printer) Fprintwt(w io.Writer, v ...interface{}) (int, error) {
+	return pr.fprintValues(w, wrap|types, v)
+}
+
+// Fprintv prints the provided objects to the provided writer with verbose type information. When multiple
+// objects are printed, they'll be separated by a space.
+func (pr Printer) Fprintv(w io.Writer, v ...interface{}) (int, error) {
+	return pr.fprintValues(w, allTypes, v)
+}
+
+// Fprintwv prints the provided objects to the provided writer, with wrapping (and indentation) where necessary,
+// and with verbose type information. When multiple objects are printed, they'll be separated by a newline.
+func (pr Printer) Fprintwv(w io.Writer, v ...interface{}) (int, error) {
+	return pr.fprintValues(w, wrap|allTypes, v)
+}
+
+// Print prints the provided objects to stderr. When multiple objects are printed, they'll be separated by a
+// space.
+func (pr Printer) Print(v ...interface{}) (int, error) {
+	return pr.printValues(none, v)
+}
+
+// Printw prints the provided objects to stderr, with wrapping (and indentation) where necessary. When multiple
+// objects are printed, they'll be separated by a newline.
+func (pr Printer) Printw(v ...interface{}) (int, error) {
+	return pr.printValues(wrap, v)
+}
+
+// Printt prints the provided objects to stderr with moderate type information. When multiple objects are
+// printed, they'll be separated by a space.
+func (pr Printer) Printt(v ...interface{}) (int, error) {
+	return pr.printValues(types, v)
+}
+
+// Printwt prints the provided objects to stderr, with wrapping (and indentation) where necessary, and with
+// moderate type information. When multiple objects are printed, they'll be separated by a newline.
+func (pr Printer) Printwt(v ...interface{}) (int, error) {
+	return pr.printValues(wrap|types, v)
+}
+
+// Printv prints the provided objects to stderr with verbose type information. When multiple objects are
+// printed, they'll be separated by a space.
+func (pr Printer) Printv(v ...interface{}) (int, error) {
+	return pr.printValues(allTypes, v)
+}
+
+// Printwv prints the provided objects to stderr, with wrapping (and indentation) where necessary, and with
+// verbose type information. When multiple objects are printed, they'll be separated by a newline.
+func (pr Printer) Printwv(v ...interface{}) (int, error) {
+	return pr.printValues(wrap|allTypes, v)
+}
+
+// Println prints the provided objects to stderr with a closing newline. When multiple objects are printed,
+// they'll be separated by a space.
+func (pr Printer) Println(v ...interface{}) (int, error) {
+	return pr.printlnValues(none, v)
+}
+
+// Printlnw prints the provided objects to stderr with a closing newline, with wrapping (and indentation) where
+// necessary. When multiple objects are printed, they'll be separated by a newline.
+func (pr Printer) Printlnw(v ...interface{}) (int, error) {
+	return pr.printlnValues(wrap, v)
+}
+
+// Printlnt prints the provided objects to stderr with a closing newline, and with moderate type information. When
+// multiple objects are printed, they'll be separated by a space.
+func (pr Printer) Printlnt(v ...interface{}) (int, error) {
+	return pr.printlnValues(types, v)
+}
+
+// Printlnwt prints the provided objects to stderr with a closing newline, with wrapping (and indentation) where
+// necessary, and with moderate type information. When multiple objects are printed, they'll be separated by a
+// newline.
+func (pr Printer) Printlnwt(v ...interface{}) (int, error) {
+	return pr.printlnValues(wrap|types, v)
+}
+
+// Printlnv prints the provided objects to stderr with a closing newline, and with verbose type information. When
+// multiple objects are printed, they'll be separated by a space.
+func (pr Printer) Printlnv(v ...interface{}) (int, error) {
+	return pr.printlnValues(allTypes, v)
+}
+
+// Printlnwv prints the provided objects to stderr with a closing newline, with wrapping (and indentation) where
+// necessary, and with verbose type information. When multiple objects are printed, they'll be separated by a
+// newline.
+func (pr Printer) Printlnwv(v ...interface{}) (int, error) {
+	return pr.printlnValues(wrap|allTypes, v)
+}
+
+// Sprint returns the string representation of the Go objects. When multiple objects are provided, they'll be
+// seprated by a space.
+func (pr Printer) Sprint(v ...interface{}) string {
+	return pr.sprintValues(none, v)
+}
+
+// Sprintw returns the string representation of the Go objects, with wrapping (and indentation) where necessary.
+// When multiple objects are provided, they'll be seprated by a newline.
+func (pr Printer) Sprintw(v ...interface{}) string {
+	return pr.sprintValues(wrap, v)
+}
+
+// Sprintt returns the string representation of the Go objects, with moderate type information. When multiple
+// objects are provided, they'll be seprated by a space.
+func (pr Printer) Sprintt(v ...interface{}) string {
+	return pr.sprintValues(types, v)
+}
+
+// Sprintwt returns the string representation of the Go objects, with wrapping (and indentation) where necessary,
+// and with moderate type information. When multiple objects are provided, they'll be seprated by a newline.
+func (pr Printer) Sprintwt(v ...interface{}) string {
+	return pr.sprintValues(wrap|types, v)
+}
+
+// Sprintv returns the string representation of the Go objects, with verbose type information. When multiple
+// objects are provided, they'll be seprated by a space.
+func (pr Printer) Sprintv(v ...interface{}) string {
+	return pr.sprintValues(allTypes, v)
+}
+
+// Sprintwv returns the string representation of the Go objects, with wrapping (and indentation) where necessary,
+// and with verbose type information. When multiple objects are provided, they'll be seprated by a newline.
+func (pr Printer) Sprintwv(v ...interface{}) string {
+	return pr.sprintValues(wrap|allTypes, v)
+}
@@ -0,0 +1,368 @@
+package notation
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOmitZero(t *testing.T) {
+	type inner struct {
+		foo int
+		bar *int
+	}
+
+	type outer struct {
+		foo  int
+		bar  string
+		baz  inner
+		qux  []int
+		quux map[string]int
+		quuz *inner
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		const expect = `{foo: 0, bar: "", baz: {foo: 0, bar: nil}, qux: nil, quux: nil, quuz: nil}`
+		s := Printer{}.Sprint(outer{})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("all zero", func(t *testing.T) {
+		const expect = `{}`
+		s := Printer{OmitZero: true}.Sprint(outer{})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		const expect = `{foo: 42, baz: {foo: 36}, quuz: {foo: 21}}`
+		o := outer{foo: 42, baz: inner{foo: 36}, quuz: &inner{foo: 21}}
+		s := Printer{OmitZero: true}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("empty but not nil", func(t *testing.T) {
+		const expect = `{qux: []{}, quux: map{}}`
+		o := outer{qux: []int{}, quux: map[string]int{}}
+		s := Printer{OmitZero: true}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("zero values in lists are kept", func(t *testing.T) {
+		const expect = `[]{{}, {foo: 42}}`
+		s := Printer{OmitZero: true}.Sprint([]inner{{}, {foo: 42}})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("map entries kept by default", func(t *testing.T) {
+		const expect = `map{"bar": 0, "foo": 42}`
+		s := Printer{OmitZero: true}.Sprint(map[string]int{"foo": 42, "bar": 0})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("map entries", func(t *testing.T) {
+		const expect = `map{"baz": {foo: 0, bar: nil}, "foo": {foo: 42, bar: nil}}`
+		s := Printer{OmitZeroMapEntries: true}.Sprint(map[string]*inner{
+			"foo": {foo: 42},
+			"bar": nil,
+			"baz": {},
+		})
+
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("with types", func(t *testing.T) {
+		const expect = `outer{foo: 42, quuz: {foo: 21}}`
+		o := outer{foo: 42, quuz: &inner{foo: 21}}
+		s := Printer{OmitZero: true}.Sprintt(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
+
+func TestExportedOnly(t *testing.T) {
+	type Inner struct {
+		Foo int
+		bar int
+	}
+
+	type Outer struct {
+		Foo   int
+		bar   int
+		baz   string
+		Inner Inner
+	}
+
+	o := Outer{Foo: 1, bar: 2, baz: "3", Inner: Inner{Foo: 4, bar: 5}}
+
+	t.Run("disabled", func(t *testing.T) {
+		const expect = `{Foo: 1, bar: 2, baz: "3", Inner: {Foo: 4, bar: 5}}`
+		s := Printer{}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("exported only", func(t *testing.T) {
+		const expect = `{Foo: 1, Inner: {Foo: 4, /* 1 hidden */}, /* 2 hidden */}`
+		s := Printer{ExportedOnly: true}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("nothing hidden", func(t *testing.T) {
+		const expect = `{Foo: 4}`
+		s := Printer{ExportedOnly: true}.Sprint(struct{ Foo int }{4})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("listed package", func(t *testing.T) {
+		const expect = `{Foo: 1, bar: 2, baz: "3", Inner: {Foo: 4, bar: 5}}`
+		s := Printer{
+			ExportedOnly:       true,
+			UnexportedPackages: []string{"github.com/aryszka/notation"},
+		}.Sprint(o)
+
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("other package listed", func(t *testing.T) {
+		const expect = `{Foo: 1, Inner: {Foo: 4, /* 1 hidden */}, /* 2 hidden */}`
+		s := Printer{UnexportedPackages: []string{"sync"}}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		const expect = `{
+	Foo: 1,
+	Inner: {
+		Foo: 4,
+		/* 1 hidden */,
+	},
+	/* 2 hidden */,
+}`
+
+		defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+		s := Printer{ExportedOnly: true}.Sprintw(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
+
+func TestEmbedded(t *testing.T) {
+	type Foo struct{ Bar, Baz int }
+	type Qux struct{ Baz, Quux int }
+	type FooQux struct {
+		Foo
+		*Qux
+		Quux string
+	}
+
+	o := FooQux{Foo: Foo{Bar: 1, Baz: 2}, Qux: &Qux{Baz: 3, Quux: 4}, Quux: "5"}
+
+	t.Run("nested", func(t *testing.T) {
+		const expect = `{Foo: {Bar: 1, Baz: 2}, Qux: {Baz: 3, Quux: 4}, Quux: "5"}`
+		s := Printer{}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("flattened, shadowed and ambiguous fields", func(t *testing.T) {
+		const expect = `{Bar: 1, Quux: "5"}`
+		s := Printer{FlattenEmbedded: true}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("flattened, nil embedded pointer", func(t *testing.T) {
+		type Bar struct {
+			*Qux
+			Foo int
+		}
+
+		const expect = `{Qux: nil, Foo: 42}`
+		s := Printer{FlattenEmbedded: true}.Sprint(Bar{Foo: 42})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("flattened, with types", func(t *testing.T) {
+		type Bar struct {
+			Qux
+			Foo int
+		}
+
+		const expect = `Bar{Baz: int(1), Quux: int(2), Foo: int(3)}`
+		s := Printer{FlattenEmbedded: true}.Sprintv(Bar{Qux: Qux{Baz: 1, Quux: 2}, Foo: 3})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("blank fields", func(t *testing.T) {
+		const expect = `{_: 0, _: "", foo: 3}`
+		o := struct {
+			_   int
+			_   string
+			foo int
+		}{foo: 3}
+
+		s := Printer{}.Sprint(o)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
+
+type multilineEnum int
+
+func (multilineEnum) String() string { return "foo\nbar" }
+
+type statusEnum int
+
+func (statusEnum) String() string { return "Status: OK, fine" }
+
+func TestSingleLine(t *testing.T) {
+	type item struct {
+		Foo string
+		Bar []int
+		Baz multilineEnum
+	}
+
+	const long = "The quick brown fox jumps over the lazy dog.\nThe quick brown fox jumps over the lazy dog."
+
+	t.Run("wrapped", func(t *testing.T) {
+		const expect = `{Foo: "The quick brown fox jumps over the lazy dog.\nThe quick brown fox jumps over the lazy dog.", ` +
+			`Bar: []{1, 2, 3}, Baz: 1 /* foo\nbar */} {Foo: "", Bar: nil, Baz: 0 /* foo\nbar */}`
+
+		defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+		s := Printer{SingleLine: true, EnumNames: true}.Sprintw(item{long, []int{1, 2, 3}, 1}, item{})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("prefer raw strings", func(t *testing.T) {
+		const expect = `"foo\nbar"`
+		s := Printer{SingleLine: true, RawStrings: PreferRawStrings}.Sprint("foo\nbar")
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		const expect = `{Foo:"a, b: c",Bar:[]{1,2,3},Baz:0}`
+		s := Printer{SingleLine: true, Compact: true}.Sprintw(item{Foo: "a, b: c", Bar: []int{1, 2, 3}})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("compact, map keys", func(t *testing.T) {
+		const expect = `map[string]int{string("a: b"):int(1)}`
+		s := Printer{Compact: true}.Sprintv(map[string]int{"a: b": 1})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("compact, enum names", func(t *testing.T) {
+		const expect = `{Status:1 /* Status: OK, fine */,Count:2}`
+		s := Printer{Compact: true, EnumNames: true}.Sprint(struct {
+			Status statusEnum
+			Count  int
+		}{1, 2})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("compact, hexdump", func(t *testing.T) {
+		const expect = `[]{00000000  61 3a 20 62 2c 20 63                              |a: b, c|}`
+		s := Printer{Compact: true, Bytes: HexdumpBytes}.Sprint([]byte("a: b, c"))
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("compact, string length", func(t *testing.T) {
+		const expect = `[]{"héllo" /* len: 6, runes: 5 */,"foo" /* len: 3 */}`
+		s := Printer{Compact: true, StringLength: true}.Sprint([]string{"héllo", "foo"})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
+
+func TestMaxDepth(t *testing.T) {
+	type node struct {
+		Value    int
+		Children []*node
+		Labels   map[string]string
+	}
+
+	tree := &node{
+		Value: 1,
+		Children: []*node{{
+			Value:    2,
+			Children: []*node{{Value: 3}},
+		}},
+		Labels: map[string]string{"foo": "bar"},
+	}
+
+	for _, test := range []struct {
+		depth  int
+		expect string
+	}{{
+		depth:  0,
+		expect: `{Value: 1, Children: []{{Value: 2, Children: []{{Value: 3, Children: nil, Labels: nil}}, Labels: nil}}, Labels: map{"foo": "bar"}}`,
+	}, {
+		depth:  1,
+		expect: `{Value: 1, Children: []{...}, Labels: map{...}}`,
+	}, {
+		depth:  2,
+		expect: `{Value: 1, Children: []{{...}}, Labels: map{"foo": "bar"}}`,
+	}, {
+		depth:  3,
+		expect: `{Value: 1, Children: []{{Value: 2, Children: []{...}, Labels: nil}}, Labels: map{"foo": "bar"}}`,
+	}} {
+		t.Run(fmt.Sprint(test.depth), func(t *testing.T) {
+			s := Printer{MaxDepth: test.depth}.Sprint(tree)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+
+	t.Run("with types", func(t *testing.T) {
+		const expect = `*node{Value: 1, Children: []{...}, Labels: map{...}}`
+		s := Printer{MaxDepth: 1}.Sprintt(tree)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
-	"strconv"
-	"strings"
+	"unsafe"
 )
 
 func withType(o opts) (opts, bool, bool) {
@@ -21,8 +20,9 @@ func withType(o opts) (opts, bool, bool) {
 	return o, true, o&allTypes != 0
 }
 
-func reflectPrimitive(o opts, r reflect.Value, v interface{}, suppressType ...string) node {
-	s := fmt.Sprint(v)
+func reflectPrimitive(o opts, c *Printer, r reflect.Value, v interface{}, suppressType ...string) node {
+	// the formatted numbers may contain the comments showing the enum names, which are not syntax:
+	s := text(fmt.Sprint(v))
 	if s[0] == '(' && s[len(s)-1] == ')' {
 		s = s[1 : len(s)-1]
 	}
@@ -32,7 +32,7 @@ func reflectPrimitive(o opts, r reflect.Value, v interface{}, suppressType ...st
 		return nodeOf(s)
 	}
 
-	tn := reflectType(r.Type())
+	tn := reflectType(c, r.Type())
 	if a {
 		return nodeOf(tn, "(", s, ")")
 	}
@@ -47,95 +47,118 @@ func reflectPrimitive(o opts, r reflect.Value, v interface{}, suppressType ...st
 	return nodeOf(tn, "(", s, ")")
 }
 
-func reflectNil(o opts, groupUnnamedType bool, r reflect.Value) node {
+func reflectNil(o opts, c *Printer, groupUnnamedType bool, r reflect.Value) node {
 	if _, _, a := withType(o); !a {
 		return nodeOf("nil")
 	}
 
 	rt := r.Type()
 	if groupUnnamedType && rt.Name() == "" {
-		return nodeOf("(", reflectType(rt), ")(nil)")
+		return nodeOf("(", reflectType(c, rt), ")(nil)")
 	}
 
-	return nodeOf(reflectType(rt), "(nil)")
+	return nodeOf(reflectType(c, rt), "(nil)")
 }
 
-func reflectItems(o opts, p *pending, prefix string, r reflect.Value) node {
+func reflectItems(o opts, c *Printer, p *pending, prefix string, r reflect.Value) node {
 	typ := r.Type()
-	var w wrapper
 	if typ.Elem().Kind() == reflect.Uint8 {
-		w.sep = " "
-		w.mode = line
-		for i := 0; i < r.Len(); i++ {
-			w.items = append(
-				w.items,
-				nodeOf(fmt.Sprintf("%02x", r.Index(i).Uint())),
-			)
-		}
-	} else {
-		w.sep = ", "
-		w.suffix = ","
-		itemOpts := o | skipTypes
-		for i := 0; i < r.Len(); i++ {
-			w.items = append(
-				w.items,
-				reflectValue(itemOpts, p, r.Index(i)),
-			)
-		}
+		return reflectBytes(o, c, prefix, r)
+	}
+
+	w := wrapper{sep: ", ", suffix: ","}
+	itemOpts := o | skipTypes
+	for i := 0; i < r.Len(); i++ {
+		w.items = append(
+			w.items,
+			reflectValue(itemOpts, c, p, r.Index(i)),
+		)
 	}
 
 	if _, t, _ := withType(o); t {
-		return nodeOf(reflectType(typ), "{", w, "}")
+		return nodeOf(reflectType(c, typ), "{", w, "}")
 	}
 
 	return nodeOf(prefix, "{", w, "}")
 }
 
-func reflectHidden(o opts, hidden string, r reflect.Value) node {
+func reflectHidden(o opts, c *Printer, hidden string, r reflect.Value) node {
 	if r.IsNil() {
-		return reflectNil(o, true, r)
+		return reflectNil(o, c, true, r)
 	}
 
 	if _, t, _ := withType(o); t {
-		return reflectType(r.Type())
+		return reflectType(c, r.Type())
 	}
 
 	return nodeOf(hidden)
 }
 
-func reflectArray(o opts, p *pending, r reflect.Value) node {
-	return reflectItems(o, p, fmt.Sprintf("[%d]", r.Len()), r)
+func reflectArray(o opts, c *Printer, p *pending, r reflect.Value) node {
+	return reflectItems(o, c, p, fmt.Sprintf("[%d]", r.Len()), r)
 }
 
-func reflectChan(o opts, r reflect.Value) node {
-	return reflectHidden(o, "chan", r)
+func reflectChan(o opts, c *Printer, p *pending, r reflect.Value) node {
+	n := reflectHidden(o, c, "chan", r)
+	if r.IsNil() || !c.ChanState && !c.UnsafeChanPeek {
+		return n
+	}
+
+	wr := wrapper{sep: ", ", suffix: ","}
+	wr.items = append(wr.items, nodeOf("len: ", r.Len()), nodeOf("cap: ", r.Cap()))
+	if !c.UnsafeChanPeek {
+		return nodeOf(n, "{", wr, "}")
+	}
+
+	closed, buffered := peekChan(r)
+	wr.items = append(wr.items, nodeOf("closed: ", closed))
+	if len(buffered) == 0 {
+		return nodeOf(n, "{", wr, "}")
+	}
+
+	items := wrapper{sep: ", ", suffix: ","}
+	for _, b := range buffered {
+		items.items = append(items.items, reflectValue(o|skipTypes, c, p, b))
+	}
+
+	wr.items = append(wr.items, nodeOf("buffered: []{", items, "}"))
+	return nodeOf(n, "{", wr, "}")
 }
 
-func reflectFunc(o opts, r reflect.Value) node {
-	return reflectHidden(o, "func()", r)
+func reflectFunc(o opts, c *Printer, r reflect.Value) node {
+	n := reflectHidden(o, c, "func()", r)
+	if !c.FuncNames || r.IsNil() {
+		return n
+	}
+
+	if info := funcInfo(r.Pointer()); info != "" {
+		return nodeOf(n, " /* ", text(info), " */")
+	}
+
+	return n
 }
 
-func reflectInterface(o opts, p *pending, r reflect.Value) node {
+func reflectInterface(o opts, c *Printer, p *pending, r reflect.Value) node {
 	if r.IsNil() {
-		return reflectNil(o, false, r)
+		return reflectNil(o, c, false, r)
 	}
 
-	e := reflectValue(o&^skipTypes, p, r.Elem())
+	e := reflectValue(o&^skipTypes, c, p, r.Elem())
 	if _, t, _ := withType(o); !t {
 		return e
 	}
 
 	return nodeOf(
-		reflectType(r.Type()),
+		reflectType(c, r.Type()),
 		"(",
 		wrapper{items: []node{e}},
 		")",
 	)
 }
 
-func reflectMap(o opts, p *pending, r reflect.Value) node {
+func reflectMap(o opts, c *Printer, p *pending, r reflect.Value) node {
 	if r.IsNil() {
-		return reflectNil(o, true, r)
+		return reflectNil(o, c, true, r)
 	}
 
 	var skeys []string
@@ -143,8 +166,12 @@ func reflectMap(o opts, p *pending, r reflect.Value) node {
 	sv := make(map[string]reflect.Value)
 	sn := make(map[string]node)
 	for _, key := range r.MapKeys() {
-		kn := reflectValue(itemOpts, p, key)
-		knExt := reflectValue(itemOpts|_pointerValues, p, key)
+		if c.OmitZeroMapEntries && r.MapIndex(key).IsZero() {
+			continue
+		}
+
+		kn := reflectValue(itemOpts, c, p, key)
+		knExt := reflectValue(itemOpts|_pointerValues, c, p, key)
 		var b bytes.Buffer
 		wr := writer{w: &b}
 		fprint(&wr, 0, knExt)
@@ -160,7 +187,13 @@ func reflectMap(o opts, p *pending, r reflect.Value) node {
 
 	w := wrapper{sep: ", ", suffix: ","}
 	for _, skey := range skeys {
-		vn := reflectValue(itemOpts, p, r.MapIndex(sv[skey]))
+		key := sv[skey]
+		if key.Kind() == reflect.String && c.redacted(key.String()) {
+			w.items = append(w.items, nodeOf(sn[skey], ": ", redactedValue))
+			continue
+		}
+
+		vn := reflectValue(itemOpts, c, p, r.MapIndex(key))
 		w.items = append(
 			w.items,
 			nodeOf(sn[skey], ": ", vn),
@@ -171,15 +204,15 @@ func reflectMap(o opts, p *pending, r reflect.Value) node {
 		return nodeOf("map{", w, "}")
 	}
 
-	return nodeOf(reflectType(r.Type()), "{", w, "}")
+	return nodeOf(reflectType(c, r.Type()), "{", w, "}")
 }
 
-func reflectPointer(o opts, p *pending, r reflect.Value) node {
+func reflectPointer(o opts, c *Printer, p *pending, r reflect.Value) node {
 	if r.IsNil() {
-		return reflectNil(o, true, r)
+		return reflectNil(o, c, true, r)
 	}
 
-	e := reflectValue(o, p, r.Elem())
+	e := reflectValue(o, c, p, r.Elem())
 	if o&_pointerValues != 0 {
 		e = nodeOf(e, "_", r.Pointer())
 	}
@@ -191,28 +224,22 @@ func reflectPointer(o opts, p *pending, r reflect.Value) node {
 	return nodeOf("*", e)
 }
 
-func reflectList(o opts, p *pending, r reflect.Value) node {
+func reflectList(o opts, c *Printer, p *pending, r reflect.Value) node {
 	if r.IsNil() {
-		return reflectNil(o, true, r)
+		return reflectNil(o, c, true, r)
 	}
 
-	return reflectItems(o, p, "[]", r)
+	return reflectItems(o, c, p, "[]", r)
 }
 
-func reflectString(o opts, r reflect.Value) node {
-	sv := r.String()
-	s := str{val: strconv.Quote(sv)}
-	if !strings.Contains(sv, "`") && strings.Contains(sv, "\n") {
-		s.raw = fmt.Sprintf("`%s`", sv)
-	}
-
-	n := nodeOf(s)
+func reflectString(o opts, c *Printer, r reflect.Value) node {
+	n := c.quotedString(r.String())
 	_, t, a := withType(o)
 	if !t {
 		return n
 	}
 
-	tn := reflectType(r.Type())
+	tn := reflectType(c, r.Type())
 	if !a && tn.parts[0] == "string" {
 		return n
 	}
@@ -220,37 +247,138 @@ func reflectString(o opts, r reflect.Value) node {
 	return nodeOf(tn, "(", wrapper{items: []node{n}}, ")")
 }
 
-func reflectStruct(o opts, p *pending, r reflect.Value) node {
+func isEmbeddedStruct(f reflect.StructField) bool {
+	if !f.Anonymous {
+		return false
+	}
+
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+// fieldByIndex returns false when the field is not reachable, because it is
+// promoted through a nil embedded pointer.
+func fieldByIndex(r reflect.Value, index []int) (reflect.Value, bool) {
+	for i, fi := range index {
+		if i > 0 && r.Kind() == reflect.Ptr {
+			if r.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			r = r.Elem()
+		}
+
+		r = r.Field(fi)
+	}
+
+	return r, true
+}
+
+func structFields(c *Printer, t reflect.Type) []reflect.StructField {
+	if !c.FlattenEmbedded {
+		fields := make([]reflect.StructField, t.NumField())
+		for i := range fields {
+			fields[i] = t.Field(i)
+		}
+
+		return fields
+	}
+
+	return reflect.VisibleFields(t)
+}
+
+// addressable returns an addressable copy of a struct when it's not
+// addressable, e.g. when it was stored in an interface or a map, so that
+// its unexported fields can be accessed with exported().
+func addressable(r reflect.Value) reflect.Value {
+	if r.CanAddr() || !r.CanInterface() {
+		return r
+	}
+
+	a := reflect.New(r.Type()).Elem()
+	a.Set(r)
+	return a
+}
+
+// exported allows calling Interface() on the values of the unexported struct
+// fields, which is required by the built-in renderers. The returned value is
+// used only for reading.
+func exported(r reflect.Value) reflect.Value {
+	if r.CanInterface() || !r.CanAddr() {
+		return r
+	}
+
+	return reflect.NewAt(r.Type(), unsafe.Pointer(r.UnsafeAddr())).Elem()
+}
+
+func reflectStruct(o opts, c *Printer, p *pending, r reflect.Value) node {
+	r = addressable(r)
 	wr := wrapper{sep: ", ", suffix: ","}
 
 	fieldOpts := o | skipTypes
 	rt := r.Type()
-	for i := 0; i < r.NumField(); i++ {
-		name := rt.Field(i).Name
-		fv := reflectValue(fieldOpts, p, r.FieldByName(name))
+	var hidden int
+	for _, f := range structFields(c, rt) {
+		fr, ok := fieldByIndex(r, f.Index)
+		if !ok {
+			continue
+		}
+
+		fr = exported(fr)
+
+		// when flattening, the embedded structs are represented by their promoted
+		// fields, except when they are nil pointers:
+		//
+		if c.FlattenEmbedded && isEmbeddedStruct(f) && (fr.Kind() != reflect.Ptr || !fr.IsNil()) {
+			continue
+		}
+
+		if c.hideUnexported(f) {
+			hidden++
+			continue
+		}
+
+		if c.redacted(f.Name) {
+			wr.items = append(wr.items, nodeOf(f.Name, ": ", redactedValue))
+			continue
+		}
+
+		if c.OmitZero && fr.IsZero() {
+			continue
+		}
+
+		fv := reflectValue(fieldOpts, fieldPrinter(c, f), p, fr)
 		wr.items = append(
 			wr.items,
-			nodeOf(name, ": ", fv),
+			nodeOf(f.Name, ": ", fv),
 		)
 	}
 
+	if hidden > 0 {
+		wr.items = append(wr.items, nodeOf("/* ", hidden, " hidden */"))
+	}
+
 	if _, t, _ := withType(o); !t {
 		return nodeOf("{", wr, "}")
 	}
 
-	return nodeOf(reflectType(rt), "{", wr, "}")
+	return nodeOf(reflectType(c, rt), "{", wr, "}")
 }
 
-func reflectUnsafePointer(o opts, r reflect.Value) node {
+func reflectUnsafePointer(o opts, c *Printer, r reflect.Value) node {
 	if r.IsNil() {
-		return reflectNil(o, false, r)
+		return reflectNil(o, c, false, r)
 	}
 
 	if _, _, a := withType(o); !a {
 		return nodeOf("pointer")
 	}
 
-	return nodeOf(reflectType(r.Type()), "(pointer)")
+	return nodeOf(reflectType(c, r.Type()), "(pointer)")
 }
 
 func checkPending(p *pending, r reflect.Value) (applyRef func(node) node, ref node, isPending bool) {
@@ -281,11 +409,7 @@ func checkPending(p *pending, r reflect.Value) (applyRef func(node) node, ref no
 	applyRef = func(n node) node {
 		nr = p.values[key]
 		if nr.refCount > 0 {
-			def := []interface{}{"r", nr.id, "="}
-			pp := make([]interface{}, len(def)+len(n.parts))
-			copy(pp, def)
-			copy(pp[len(def):], n.parts)
-			n.parts = pp
+			n = labelRef(nr.id, n)
 		}
 
 		delete(p.values, key)
@@ -295,23 +419,72 @@ func checkPending(p *pending, r reflect.Value) (applyRef func(node) node, ref no
 	return
 }
 
-func reflectValue(o opts, p *pending, r reflect.Value) node {
-	applyRef, ref, isPending := checkPending(p, r)
-	if isPending {
+// isContainer tells whether a value counts as a level of nesting when limiting the depth. The pointers and
+// the interfaces don't, neither the byte slices and arrays.
+func isContainer(r reflect.Value) bool {
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		return r.Len() > 0 && r.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return r.Len() > 0
+	case reflect.Struct:
+		return r.NumField() > 0
+	default:
+		return false
+	}
+}
+
+func reflectElided(o opts, c *Printer, r reflect.Value) node {
+	if _, t, _ := withType(o); t {
+		return nodeOf(reflectType(c, r.Type()), "{...}")
+	}
+
+	switch r.Kind() {
+	case reflect.Array:
+		return nodeOf("[", r.Len(), "]{...}")
+	case reflect.Slice:
+		return nodeOf("[]{...}")
+	case reflect.Map:
+		return nodeOf("map{...}")
+	default:
+		return nodeOf("{...}")
+	}
+}
+
+func reflectValue(o opts, c *Printer, p *pending, r reflect.Value) node {
+	applyRef, ref, isRef := checkShared(c, p, r)
+	if applyRef == nil && !isRef {
+		applyRef, ref, isRef = checkPending(p, r)
+	}
+
+	if isRef {
 		return ref
 	}
 
-	var n node
+	n, ok := reflectBuiltin(o, c, p, r)
+	if ok {
+		return applyRef(n)
+	}
+
+	if isContainer(r) {
+		if c.MaxDepth > 0 && p.depth >= c.MaxDepth {
+			return applyRef(reflectElided(o, c, r))
+		}
+
+		p.depth++
+		defer func() { p.depth-- }()
+	}
+
 	switch r.Kind() {
 	case reflect.Bool:
-		n = reflectPrimitive(o, r, r.Bool(), "bool")
+		n = reflectPrimitive(o, c, r, r.Bool(), "bool")
 	case
 		reflect.Int,
 		reflect.Int8,
 		reflect.Int16,
 		reflect.Int32,
 		reflect.Int64:
-		n = reflectPrimitive(o, r, r.Int(), "int")
+		n = reflectPrimitive(o, c, r, c.formatInt(r), "int")
 	case
 		reflect.Uint,
 		reflect.Uint8,
@@ -319,31 +492,31 @@ func reflectValue(o opts, p *pending, r reflect.Value) node {
 		reflect.Uint32,
 		reflect.Uint64,
 		reflect.Uintptr:
-		n = reflectPrimitive(o, r, r.Uint())
+		n = reflectPrimitive(o, c, r, c.formatInt(r))
 	case reflect.Float32, reflect.Float64:
-		n = reflectPrimitive(o, r, r.Float())
+		n = reflectPrimitive(o, c, r, c.formatFloat(r))
 	case reflect.Complex64, reflect.Complex128:
-		n = reflectPrimitive(o, r, r.Complex())
+		n = reflectPrimitive(o, c, r, c.formatComplex(r))
 	case reflect.Array:
-		n = reflectArray(o, p, r)
+		n = reflectArray(o, c, p, r)
 	case reflect.Chan:
-		n = reflectChan(o, r)
+		n = reflectChan(o, c, p, r)
 	case reflect.Func:
-		n = reflectFunc(o, r)
+		n = reflectFunc(o, c, r)
 	case reflect.Interface:
-		n = reflectInterface(o, p, r)
+		n = reflectInterface(o, c, p, r)
 	case reflect.Map:
-		n = reflectMap(o, p, r)
+		n = reflectMap(o, c, p, r)
 	case reflect.Ptr:
-		n = reflectPointer(o, p, r)
+		n = reflectPointer(o, c, p, r)
 	case reflect.Slice:
-		n = reflectList(o, p, r)
+		n = reflectList(o, c, p, r)
 	case reflect.String:
-		n = reflectString(o, r)
+		n = reflectString(o, c, r)
 	case reflect.UnsafePointer:
-		n = reflectUnsafePointer(o, r)
+		n = reflectUnsafePointer(o, c, r)
 	default:
-		n = reflectStruct(o, p, r)
+		n = reflectStruct(o, c, p, r)
 	}
 
 	return applyRef(n)
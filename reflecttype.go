@@ -2,17 +2,17 @@ package notation
 
 import "reflect"
 
-func reflectFuncBaseType(t reflect.Type) node {
+func reflectFuncBaseType(c *Printer, t reflect.Type) node {
 	isVariadic := t.IsVariadic()
 	args := func(num func() int, typ func(int) reflect.Type) []node {
 		var t []node
 		for i := 0; i < num(); i++ {
 			if i == num()-1 && isVariadic {
-				t = append(t, nodeOf("...", reflectType(typ(i).Elem())))
+				t = append(t, nodeOf("...", reflectType(c, typ(i).Elem())))
 				continue
 			}
 
-			t = append(t, reflectType(typ(i)))
+			t = append(t, reflectType(c, typ(i)))
 		}
 
 		return t
@@ -38,11 +38,11 @@ func reflectFuncBaseType(t reflect.Type) node {
 	return n
 }
 
-func reflectArrayType(t reflect.Type) node {
-	return nodeOf("[", t.Len(), "]", reflectType(t.Elem()))
+func reflectArrayType(c *Printer, t reflect.Type) node {
+	return nodeOf("[", t.Len(), "]", reflectType(c, t.Elem()))
 }
 
-func reflectChanType(t reflect.Type) node {
+func reflectChanType(c *Printer, t reflect.Type) node {
 	var prefix string
 	switch t.ChanDir() {
 	case reflect.RecvDir:
@@ -53,73 +53,68 @@ func reflectChanType(t reflect.Type) node {
 		prefix = "chan "
 	}
 
-	return nodeOf(prefix, reflectType(t.Elem()))
+	return nodeOf(prefix, reflectType(c, t.Elem()))
 }
 
-func reflectFuncType(t reflect.Type) node {
-	return nodeOf("func", reflectFuncBaseType(t))
+func reflectFuncType(c *Printer, t reflect.Type) node {
+	return nodeOf("func", reflectFuncBaseType(c, t))
 }
 
-func reflectInterfaceType(t reflect.Type) node {
+func reflectInterfaceType(c *Printer, t reflect.Type) node {
 	wr := wrapper{sep: "; "}
 	for i := 0; i < t.NumMethod(); i++ {
 		method := t.Method(i)
-		mn := nodeOf(method.Name, reflectFuncBaseType(method.Type))
+		mn := nodeOf(method.Name, reflectFuncBaseType(c, method.Type))
 		wr.items = append(wr.items, mn)
 	}
 
 	return nodeOf("interface{", wr, "}")
 }
 
-func reflectMapType(t reflect.Type) node {
-	return nodeOf("map[", reflectType(t.Key()), "]", reflectType(t.Elem()))
+func reflectMapType(c *Printer, t reflect.Type) node {
+	return nodeOf("map[", reflectType(c, t.Key()), "]", reflectType(c, t.Elem()))
 }
 
-func reflectPointerType(t reflect.Type) node {
-	return nodeOf("*", reflectType(t.Elem()))
+func reflectPointerType(c *Printer, t reflect.Type) node {
+	return nodeOf("*", reflectType(c, t.Elem()))
 }
 
-func reflectListType(t reflect.Type) node {
-	return nodeOf("[]", reflectType(t.Elem()))
+func reflectListType(c *Printer, t reflect.Type) node {
+	return nodeOf("[]", reflectType(c, t.Elem()))
 }
 
-func reflectStructType(t reflect.Type) node {
+func reflectStructType(c *Printer, t reflect.Type) node {
 	wr := wrapper{sep: "; "}
 	for i := 0; i < t.NumField(); i++ {
 		fi := t.Field(i)
-		fn := nodeOf(fi.Name, " ", reflectType(fi.Type))
+		fn := nodeOf(fi.Name, " ", reflectType(c, fi.Type))
 		wr.items = append(wr.items, fn)
 	}
 
 	return nodeOf("struct{", wr, "}")
 }
 
-func reflectType(t reflect.Type) node {
+func reflectType(c *Printer, t reflect.Type) node {
 	if t.Name() != "" {
-		name := t.Name()
-		if name == "uint8" {
-			name = "byte"
-		}
-
-		return nodeOf(name)
+		return nodeOf(c.typeName(t))
 	}
 
 	switch t.Kind() {
 	case reflect.Array:
-		return reflectArrayType(t)
+		return reflectArrayType(c, t)
 	case reflect.Chan:
-		return reflectChanType(t)
+		return reflectChanType(c, t)
 	case reflect.Func:
-		return reflectFuncType(t)
+		return reflectFuncType(c, t)
 	case reflect.Interface:
-		return reflectInterfaceType(t)
+		return reflectInterfaceType(c, t)
 	case reflect.Map:
-		return reflectMapType(t)
+		return reflectMapType(c, t)
 	case reflect.Ptr:
-		return reflectPointerType(t)
+		return reflectPointerType(c, t)
 	case reflect.Slice:
-		return reflectListType(t)
+		return reflectListType(c, t)
 	default:
-		return reflectStructType(t)
+		return reflectStructType(c, t)
 	}
 }
@@ -0,0 +1,309 @@
+package notation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// badPath is printed, followed by the parse error, when the selector set in Printer.Select is invalid.
+const badPath = "!BADPATH"
+
+type selectorKind int
+
+const (
+	selectField selectorKind = iota
+	selectKey
+	selectAny
+	selectDescendant
+)
+
+// selectorStep is a single step of a parsed selector. The keys are stored in the same format as the map
+// keys are printed, and the indexes of the lists are stored the same way as the integer keys.
+type selectorStep struct {
+	kind selectorKind
+	name string
+}
+
+type selector []selectorStep
+
+func isNameChar(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+func parseName(s string) (string, string, error) {
+	if strings.HasPrefix(s, "*") {
+		return "*", s[1:], nil
+	}
+
+	n := strings.IndexFunc(s, func(c rune) bool { return !isNameChar(c) })
+	if n < 0 {
+		n = len(s)
+	}
+
+	if n == 0 {
+		return "", "", errors.New("missing field name")
+	}
+
+	return s[:n], s[n:], nil
+}
+
+func parseBracket(s string) (selectorStep, string, error) {
+	if strings.HasPrefix(s, "*]") {
+		return selectorStep{kind: selectAny}, s[2:], nil
+	}
+
+	if strings.HasPrefix(s, `"`) {
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return selectorStep{}, "", fmt.Errorf("invalid map key: %s", s)
+		}
+
+		if !strings.HasPrefix(s[len(q):], "]") {
+			return selectorStep{}, "", errors.New("unclosed bracket")
+		}
+
+		// normalizing the quoting to the printed format:
+		key, _ := strconv.Unquote(q)
+		return selectorStep{kind: selectKey, name: strconv.Quote(key)}, s[len(q)+1:], nil
+	}
+
+	n := strings.IndexByte(s, ']')
+	if n < 0 {
+		return selectorStep{}, "", errors.New("unclosed bracket")
+	}
+
+	i, err := strconv.Atoi(s[:n])
+	if err != nil {
+		return selectorStep{}, "", fmt.Errorf("invalid index: %s", s[:n])
+	}
+
+	return selectorStep{kind: selectKey, name: strconv.Itoa(i)}, s[n+1:], nil
+}
+
+// parseSelector parses selectors like .driveTrain.brakes[1], .labels["app"], .items[*].name or ..name.
+func parseSelector(s string) (selector, error) {
+	var sel selector
+	for len(s) > 0 {
+		var (
+			step selectorStep
+			err  error
+		)
+
+		switch {
+		case strings.HasPrefix(s, ".."):
+			step.kind = selectDescendant
+			step.name, s, err = parseName(s[2:])
+			if step.name == "*" {
+				err = errors.New("recursive descent requires a field name")
+			}
+		case s[0] == '.':
+			step.name, s, err = parseName(s[1:])
+			if step.name == "*" {
+				step = selectorStep{kind: selectAny}
+			}
+		case s[0] == '[':
+			step, s, err = parseBracket(s[1:])
+		case s[0] == '*':
+			step.kind = selectAny
+			s = s[1:]
+		default:
+			err = fmt.Errorf("unexpected character: %q", s[0])
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		sel = append(sel, step)
+	}
+
+	if len(sel) == 0 {
+		return nil, errors.New("empty selector")
+	}
+
+	return sel, nil
+}
+
+func (s selectorStep) matches(step PathStep) bool {
+	switch s.kind {
+	case selectAny:
+		return true
+	case selectKey:
+		switch step.Kind {
+		case IndexStep:
+			return s.name == strconv.Itoa(step.Index)
+		case KeyStep:
+			return s.name == sprintValue(step.Key)
+		default:
+			return false
+		}
+	default:
+		return step.Kind == FieldStep && s.name == step.Name
+	}
+}
+
+// match returns the positions in the selector that the path can reach. The dereferencing steps are ignored.
+func (sel selector) match(path Path) map[int]bool {
+	states := map[int]bool{0: true}
+	for _, step := range path {
+		if step.Kind == DerefStep {
+			continue
+		}
+
+		next := make(map[int]bool)
+		for i := range states {
+			if i == len(sel) {
+				continue
+			}
+
+			if sel[i].kind == selectDescendant {
+				next[i] = true
+			}
+
+			if sel[i].matches(step) {
+				next[i+1] = true
+			}
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+
+		states = next
+	}
+
+	return states
+}
+
+func stepName(step PathStep) (string, bool) {
+	switch {
+	case step.Kind == FieldStep:
+		return step.Name, true
+	case step.Kind == KeyStep && step.Key.Kind() == reflect.String:
+		return step.Key.String(), true
+	default:
+		return "", false
+	}
+}
+
+// walkPrintedFields visits the struct fields the same way as reflectStruct prints them, and so the selection
+// doesn't reveal the fields that the printer hides. The values of the unexported fields are made readable for
+// the built-in renderers. While visiting a field, the printer of the walker is the one that applies the
+// options of the field tag.
+func (w *walker) walkPrintedFields(path Path, r reflect.Value) bool {
+	c := w.printer
+	r = addressable(r)
+	for _, f := range structFields(c, r.Type()) {
+		fr, ok := fieldByIndex(r, f.Index)
+		if !ok {
+			continue
+		}
+
+		fr = exported(fr)
+		if c.FlattenEmbedded && isEmbeddedStruct(f) && (fr.Kind() != reflect.Ptr || !fr.IsNil()) {
+			continue
+		}
+
+		if c.hideUnexported(f) || c.OmitZero && fr.IsZero() && !c.redacted(f.Name) {
+			continue
+		}
+
+		w.printer = fieldPrinter(c, f)
+		ok = w.walk(path.append(PathStep{Kind: FieldStep, Name: f.Name}), fr)
+		w.printer = c
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+type selected struct {
+	path     Path
+	value    reflect.Value
+	redacted bool
+	printer  *Printer
+}
+
+// selectValues finds the values matching the selector. The values nested in a matched value are not
+// matched again, because they are printed as part of it. The redacted values are not searched, and the struct
+// fields and map entries that the printer omits are not matched.
+func (pr *Printer) selectValues(sel selector, v interface{}) []selected {
+	var matches []selected
+	w := &walker{inProgress: make(map[refKey]bool), printer: pr}
+	w.visit = func(path Path, r reflect.Value) WalkAction {
+		var redacted bool
+		if len(path) > 0 {
+			name, ok := stepName(path[len(path)-1])
+			redacted = ok && pr.redacted(name)
+		}
+
+		states := sel.match(path)
+		if states[len(sel)] {
+			matches = append(matches, selected{path: path, value: r, redacted: redacted, printer: w.printer})
+			return Skip
+		}
+
+		if len(states) == 0 || redacted {
+			return Skip
+		}
+
+		return Continue
+	}
+
+	w.walk(nil, reflect.ValueOf(v))
+	return matches
+}
+
+// fprintSelected prints only the values matching the selector set in the Select option, each labeled with
+// its path, e.g. .driveTrain.brakes[1]: {pads: 2}. Like the key-value pairs, the matched values share the
+// reference tracking.
+func (pr Printer) fprintSelected(w io.Writer, o opts, v []interface{}) (int, error) {
+	o, l := pr.setup(o, v)
+	wr := pr.newWriter(w)
+	sel, err := parseSelector(pr.Select)
+	if err != nil {
+		wr.write(fmt.Sprintf("%s(%v)", badPath, err))
+		return wr.n, wr.err
+	}
+
+	var matches []selected
+	for _, vi := range v {
+		matches = append(matches, pr.selectValues(sel, vi)...)
+	}
+
+	p := newPending()
+	paths := make([]string, len(matches))
+	nodes := make([]node, len(matches))
+	for i, m := range matches {
+		paths[i] = m.path.String()
+		nodes[i] = redactedValue
+		if !m.redacted {
+			nodes[i] = reflectValue(o, m.printer, p, m.value)
+		}
+	}
+
+	return l.fprintLabeled(wr, o, paths, nodes)
+}
+
+// SprintAt returns the string representation of only those parts of the Go object that match the path
+// selector, each labeled with its path, e.g. .driveTrain.brakes[1]: {pads: 2}. The selector consists of
+// field names, e.g. .driveTrain, list indexes, e.g. [1], map keys, e.g. ["app"], wildcards matching any
+// field, item or entry, e.g. .items[*] or .*, and recursive descent matching the fields with the given
+// name at any depth, e.g. ..name.
+func SprintAt(v interface{}, path string) string {
+	return Printer{}.SprintAt(v, path)
+}
+
+// SprintAt returns the string representation of only those parts of the Go object that match the path
+// selector, with the options of the printer.
+func (pr Printer) SprintAt(v interface{}, path string) string {
+	pr.Select = path
+	return pr.Sprint(v)
+}
@@ -0,0 +1,180 @@
+package notation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelect(t *testing.T) {
+	type brake struct {
+		Pads int
+		wear float64
+	}
+
+	type driveTrain struct {
+		Brakes []brake
+		Labels map[string]string
+	}
+
+	type car struct {
+		Name       string
+		DriveTrain *driveTrain
+		Spare      interface{}
+		Password   string
+		token      string
+		timeout    time.Duration
+	}
+
+	v := car{
+		Name: "foo",
+		DriveTrain: &driveTrain{
+			Brakes: []brake{{Pads: 2, wear: 0.1}, {Pads: 4, wear: 0.3}},
+			Labels: map[string]string{"name": "front", "axle": "rear", "spare": ""},
+		},
+		Spare:    brake{Pads: 1},
+		Password: "secret",
+		token:    "t",
+		timeout:  3 * time.Second,
+	}
+
+	for _, test := range []struct {
+		title   string
+		path    string
+		printer Printer
+		print   func(Printer, ...interface{}) string
+		expect  string
+	}{{
+		title:  "field",
+		path:   ".Name",
+		expect: `.Name: "foo"`,
+	}, {
+		title:  "through pointer",
+		path:   ".DriveTrain.Brakes[1]",
+		expect: ".DriveTrain.Brakes[1]: {Pads: 4, wear: 0.3}",
+	}, {
+		title:  "map key",
+		path:   `.DriveTrain.Labels["axle"]`,
+		expect: `.DriveTrain.Labels["axle"]: "rear"`,
+	}, {
+		title:  "wildcard",
+		path:   ".DriveTrain.Brakes[*].Pads",
+		expect: ".DriveTrain.Brakes[0].Pads: 2, .DriveTrain.Brakes[1].Pads: 4",
+	}, {
+		title:  "dot wildcard",
+		path:   ".DriveTrain.*",
+		expect: `.DriveTrain.Brakes: []{{Pads: 2, wear: 0.1}, {Pads: 4, wear: 0.3}}, .DriveTrain.Labels: map{"axle": "rear", "name": "front", "spare": ""}`,
+	}, {
+		title:  "recursive descent",
+		path:   "..Pads",
+		expect: ".DriveTrain.Brakes[0].Pads: 2, .DriveTrain.Brakes[1].Pads: 4, .Spare.Pads: 1",
+	}, {
+		title:  "recursive descent does not match map keys",
+		path:   "..name",
+		expect: "",
+	}, {
+		title:  "no match",
+		path:   ".DriveTrain.Brakes[2]",
+		expect: "",
+	}, {
+		title:  "with types",
+		path:   ".Spare",
+		print:  Printer.Sprintt,
+		expect: ".Spare: brake{Pads: 1, wear: 0}",
+	}, {
+		title:  "wrapped",
+		path:   ".DriveTrain.Brakes[*]",
+		print:  Printer.Sprintw,
+		expect: ".DriveTrain.Brakes[0]: {Pads: 2, wear: 0.1}\n.DriveTrain.Brakes[1]: {Pads: 4, wear: 0.3}",
+	}, {
+		title:   "redacted",
+		path:    ".Password",
+		printer: Printer{Redact: []string{"Password"}},
+		expect:  ".Password: /* redacted */",
+	}, {
+		title:   "redacted values are not searched",
+		path:    ".DriveTrain.Brakes[0].Pads",
+		printer: Printer{Redact: []string{"DriveTrain"}},
+		expect:  "",
+	}, {
+		title:  "unexported field with built-in renderer",
+		path:   ".timeout",
+		expect: ".timeout: 3s",
+	}, {
+		title:   "exported only, recursive descent",
+		path:    "..wear",
+		printer: Printer{ExportedOnly: true},
+		expect:  "",
+	}, {
+		title:   "exported only, wildcard",
+		path:    ".*",
+		printer: Printer{ExportedOnly: true, Redact: []string{"DriveTrain"}},
+		expect:  `.Name: "foo", .DriveTrain: /* redacted */, .Spare: {Pads: 1, /* 1 hidden */}, .Password: "secret"`,
+	}, {
+		title:   "unexported packages",
+		path:    ".token",
+		printer: Printer{UnexportedPackages: []string{"example.org/other"}},
+		expect:  "",
+	}, {
+		title:   "omit zero",
+		path:    "..wear",
+		printer: Printer{OmitZero: true},
+		expect:  ".DriveTrain.Brakes[0].wear: 0.1, .DriveTrain.Brakes[1].wear: 0.3",
+	}, {
+		title:   "omit zero, wildcard",
+		path:    ".Spare.*",
+		printer: Printer{OmitZero: true},
+		expect:  ".Spare.Pads: 1",
+	}, {
+		title:   "omit zero map entries",
+		path:    `.DriveTrain.Labels[*]`,
+		printer: Printer{OmitZeroMapEntries: true},
+		expect:  `.DriveTrain.Labels["axle"]: "rear", .DriveTrain.Labels["name"]: "front"`,
+	}, {
+		title:  "invalid selector",
+		path:   ".DriveTrain.Brakes[1",
+		expect: "!BADPATH(unclosed bracket)",
+	}, {
+		title:  "invalid index",
+		path:   ".DriveTrain.Brakes[one]",
+		expect: "!BADPATH(invalid index: one)",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			pr := test.printer
+			pr.Select = test.path
+			print := test.print
+			if print == nil {
+				print = Printer.Sprint
+			}
+
+			s := print(pr, v)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+
+	t.Run("field tags", func(t *testing.T) {
+		type item struct {
+			B []byte `notation:"bytes=string"`
+		}
+
+		v := map[string]item{"x": {B: []byte("hi")}}
+		for _, test := range []struct{ path, expect string }{
+			{`["x"]`, `["x"]: {B: []byte("hi")}`},
+			{`["x"].B`, `["x"].B: []byte("hi")`},
+		} {
+			s := SprintAt(v, test.path)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		}
+	})
+
+	t.Run("SprintAt", func(t *testing.T) {
+		s := SprintAt(v, ".DriveTrain.Brakes[0].Pads")
+		expect := ".DriveTrain.Brakes[0].Pads: 2"
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
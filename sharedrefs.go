@@ -0,0 +1,142 @@
+package notation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// refKey identifies the pointers and maps that are referenced from multiple places. The type is part of the
+// key, because a pointer to a struct and a pointer to its first field have the same address. The length is
+// used only for slices, to avoid walking the same slice multiple times.
+type refKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+func collectRefs(counts map[refKey]int, visited map[refKey]bool, r reflect.Value) {
+	if !r.IsValid() {
+		return
+	}
+
+	switch r.Kind() {
+	case reflect.Map, reflect.Ptr:
+		if r.IsNil() {
+			return
+		}
+
+		// the pointers to zero sized values may share the same address:
+		if r.Kind() == reflect.Ptr && r.Type().Elem().Size() == 0 {
+			return
+		}
+
+		key := refKey{ptr: r.Pointer(), typ: r.Type()}
+		counts[key]++
+		if counts[key] > 1 {
+			return
+		}
+	case reflect.Slice:
+		if r.IsNil() {
+			return
+		}
+
+		key := refKey{ptr: r.Pointer(), typ: r.Type(), len: r.Len()}
+		if visited[key] {
+			return
+		}
+
+		visited[key] = true
+	}
+
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < r.Len(); i++ {
+			collectRefs(counts, visited, r.Index(i))
+		}
+	case reflect.Interface, reflect.Ptr:
+		collectRefs(counts, visited, r.Elem())
+	case reflect.Map:
+		for _, key := range r.MapKeys() {
+			collectRefs(counts, visited, key)
+			collectRefs(counts, visited, r.MapIndex(key))
+		}
+	case reflect.Struct:
+		for i := 0; i < r.NumField(); i++ {
+			collectRefs(counts, visited, r.Field(i))
+		}
+	}
+}
+
+// sharedRefs finds the pointers and the maps that are referenced from more than one place in the printed
+// values.
+func sharedRefs(v []interface{}) map[refKey]bool {
+	counts := make(map[refKey]int)
+	visited := make(map[refKey]bool)
+	for _, vi := range v {
+		collectRefs(counts, visited, reflect.ValueOf(vi))
+	}
+
+	shared := make(map[refKey]bool)
+	for key, count := range counts {
+		if count > 1 {
+			shared[key] = true
+		}
+	}
+
+	return shared
+}
+
+// sharedLabel is the label of the first occurrence of a shared value. It is printed only when the value is
+// referenced from another place of the output, which is known only after all the printed values were
+// processed. This way, no label is printed when the other occurrences are not printed, e.g. because they are
+// in hidden fields or below MaxDepth.
+type sharedLabel struct {
+	id         int
+	referenced bool
+}
+
+func (l *sharedLabel) String() string {
+	if !l.referenced {
+		return ""
+	}
+
+	return fmt.Sprintf("r%d=", l.id)
+}
+
+func prependParts(n node, parts ...interface{}) node {
+	pp := make([]interface{}, len(parts)+len(n.parts))
+	copy(pp, parts)
+	copy(pp[len(parts):], n.parts)
+	n.parts = pp
+	return n
+}
+
+func labelRef(id int, n node) node {
+	return prependParts(n, "r", id, "=")
+}
+
+// checkShared is like checkPending, but for the values that are referenced from multiple places, when
+// SharedRefs is enabled. The first occurrence is always labeled, and all the further occurrences, including
+// those in the subsequent values of the same print call, are printed as references. When the value is not
+// shared, it returns a nil applyRef.
+func checkShared(c *Printer, p *pending, r reflect.Value) (applyRef func(node) node, ref node, isRef bool) {
+	if c.sharedRefs == nil || r.Kind() != reflect.Map && r.Kind() != reflect.Ptr || r.IsNil() {
+		return
+	}
+
+	key := refKey{ptr: r.Pointer(), typ: r.Type()}
+	if !c.sharedRefs[key] {
+		return
+	}
+
+	if l, ok := p.shared[key]; ok {
+		l.referenced = true
+		return nil, nodeOf("r", l.id), true
+	}
+
+	l := &sharedLabel{id: p.idCounter}
+	p.idCounter++
+	p.shared[key] = l
+	applyRef = func(n node) node { return prependParts(n, l) }
+	return
+}
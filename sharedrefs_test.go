@@ -0,0 +1,135 @@
+package notation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSharedRefs(t *testing.T) {
+	type child struct{ Name string }
+	type parent struct {
+		Name     string
+		Children []*child
+		Favorite *child
+	}
+
+	c := &child{Name: "foo"}
+	p := &parent{Name: "bar", Children: []*child{c, {Name: "baz"}}, Favorite: c}
+
+	t.Run("disabled", func(t *testing.T) {
+		const expect = `{Name: "bar", Children: []{{Name: "foo"}, {Name: "baz"}}, Favorite: {Name: "foo"}} {Name: "foo"}`
+		s := Printer{}.Sprint(p, c)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("across values", func(t *testing.T) {
+		const expect = `{Name: "bar", Children: []{r2={Name: "foo"}, {Name: "baz"}}, Favorite: r2} r2`
+		s := Printer{SharedRefs: true}.Sprint(p, c)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		m := map[string]int{"foo": 42}
+		const expect = `{foo: r0=map{"foo": 42}, bar: r0}`
+		s := Printer{SharedRefs: true}.Sprint(struct{ foo, bar map[string]int }{m, m})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("cycles", func(t *testing.T) {
+		type node struct {
+			Next  *node
+			Value int
+		}
+
+		n := &node{Value: 1}
+		n.Next = n
+		const expect = `r0={Next: r0, Value: 1} r0`
+		s := Printer{SharedRefs: true}.Sprint(n, n)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("pointer to the first field", func(t *testing.T) {
+		type inner struct{ Foo int }
+		o := &struct{ Inner inner }{}
+		const expect = `{Inner: {Foo: 0}} {Foo: 0}`
+		s := Printer{SharedRefs: true}.Sprint(o, &o.Inner)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("other occurrence not printed", func(t *testing.T) {
+		type inner struct{ P *int }
+		type item struct {
+			A *int
+			B inner
+			c *int
+		}
+
+		x := 5
+		v := item{A: &x, B: inner{&x}, c: &x}
+		loc := time.FixedZone("CET", 3600)
+		for _, test := range []struct {
+			title   string
+			printer Printer
+			value   interface{}
+			expect  string
+		}{{
+			title:   "max depth",
+			printer: Printer{MaxDepth: 1},
+			value: struct {
+				A *int
+				B inner
+			}{&x, inner{&x}},
+			expect: `{A: 5, B: {...}}`,
+		}, {
+			title:   "exported only",
+			printer: Printer{ExportedOnly: true, MaxDepth: 1},
+			value:   v,
+			expect:  `{A: 5, B: {...}, /* 1 hidden */}`,
+		}, {
+			title:   "select",
+			printer: Printer{Select: ".A"},
+			value:   v,
+			expect:  `.A: 5`,
+		}, {
+			title:   "select, referenced",
+			printer: Printer{Select: "..P"},
+			value:   struct{ A, B inner }{inner{&x}, inner{&x}},
+			expect:  `.A.P: r0=5, .B.P: r0`,
+		}, {
+			title:   "built-in renderer",
+			printer: Printer{},
+			value: struct {
+				T time.Time
+				L *time.Location
+			}{time.Date(2024, 1, 2, 3, 4, 5, 0, loc), loc},
+			expect: `{T: 2024-01-02T03:04:05+01:00 CET, L: CET}`,
+		}} {
+			t.Run(test.title, func(t *testing.T) {
+				pr := test.printer
+				pr.SharedRefs = true
+				s := pr.Sprint(test.value)
+				if s != test.expect {
+					t.Fatalf("expected: %s, got: %s", test.expect, s)
+				}
+			})
+		}
+	})
+
+	t.Run("key-value pairs", func(t *testing.T) {
+		const expect = `parent: {Name: "bar", Children: []{r2={Name: "foo"}, {Name: "baz"}}, Favorite: r2}, child: r2`
+		s := Printer{SharedRefs: true}.Sprintkv("parent", p, "child", c)
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
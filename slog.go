@@ -0,0 +1,138 @@
+package notation
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+)
+
+type logValuer struct {
+	printer Printer
+	value   interface{}
+}
+
+// SlogOptions configures the slog.Handler returned by NewSlogHandler.
+type SlogOptions struct {
+
+	// Printer is used to render the attribute values, e.g. with MaxDepth or Redact set.
+	Printer Printer
+
+	// Wrap, when set, prints the rendered values with wrapping (and indentation) where necessary. By
+	// default, the values are printed on a single line, the same way as with the SingleLine option.
+	Wrap bool
+}
+
+type slogHandler struct {
+	handler slog.Handler
+	options SlogOptions
+}
+
+// Slog wraps a Go object as a slog.LogValuer, so that it is printed with notation, on a single line, when
+// used as the value of a log attribute. The object is rendered only when the record is actually logged, e.g.
+// slog.Debug("state", "value", notation.Slog(x)).
+func Slog(v interface{}) slog.LogValuer {
+	return Printer{}.Slog(v)
+}
+
+// Slog wraps a Go object as a slog.LogValuer, so that it is printed with the options of the printer, on a
+// single line, when used as the value of a log attribute.
+func (pr Printer) Slog(v interface{}) slog.LogValuer {
+	pr.SingleLine = true
+	return logValuer{printer: pr, value: v}
+}
+
+// LogValue implements slog.LogValuer.
+func (v logValuer) LogValue() slog.Value {
+	return slog.StringValue(v.printer.Sprint(v.value))
+}
+
+// NewSlogHandler returns a slog.Handler that renders the attribute values of struct, map, slice and array
+// kinds, or pointers to them, with notation, and passes the records to the provided handler. Other values,
+// including errors, are passed on unchanged. The attributes whose key is listed in the Redact option of the
+// printer are replaced by a comment, regardless of their kind.
+func NewSlogHandler(h slog.Handler, o SlogOptions) slog.Handler {
+	return slogHandler{handler: h, options: o}
+}
+
+func (h slogHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.handler.Enabled(ctx, l)
+}
+
+func (h slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(h.attr(a))
+		return true
+	})
+
+	return h.handler.Handle(ctx, nr)
+}
+
+func (h slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	rendered := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		rendered[i] = h.attr(a)
+	}
+
+	return slogHandler{handler: h.handler.WithAttrs(rendered), options: h.options}
+}
+
+func (h slogHandler) WithGroup(name string) slog.Handler {
+	return slogHandler{handler: h.handler.WithGroup(name), options: h.options}
+}
+
+func (h slogHandler) sprint(v interface{}) string {
+	if h.options.Wrap {
+		return h.options.Printer.Sprintw(v)
+	}
+
+	pr := h.options.Printer
+	pr.SingleLine = true
+	return pr.Sprint(v)
+}
+
+func isComposite(v interface{}) bool {
+	if _, ok := v.(error); ok {
+		return false
+	}
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h slogHandler) attr(a slog.Attr) slog.Attr {
+	if h.options.Printer.redacted(a.Key) {
+		return slog.String(a.Key, redactedText)
+	}
+
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindGroup:
+		group := v.Group()
+		rendered := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			rendered[i] = h.attr(ga)
+		}
+
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(rendered...)}
+	case slog.KindAny:
+		if isComposite(v.Any()) {
+			return slog.String(a.Key, h.sprint(v.Any()))
+		}
+	}
+
+	return slog.Attr{Key: a.Key, Value: v}
+}
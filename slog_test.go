@@ -0,0 +1,115 @@
+package notation
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type countingEnum int
+
+var countingEnumCalls int
+
+func (countingEnum) String() string {
+	countingEnumCalls++
+	return "counted"
+}
+
+func testLogger(b *bytes.Buffer, o *SlogOptions) *slog.Logger {
+	var h slog.Handler = slog.NewTextHandler(b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	})
+
+	if o != nil {
+		h = NewSlogHandler(h, *o)
+	}
+
+	return slog.New(h)
+}
+
+func TestSlog(t *testing.T) {
+	type user struct {
+		Name     string
+		Password string
+		Groups   []string
+	}
+
+	u := user{Name: "foo", Password: "bar", Groups: []string{"baz", "qux"}}
+
+	t.Run("log valuer", func(t *testing.T) {
+		const expect = `level=INFO msg=login user="{Name: \"foo\", Password: \"bar\", Groups: []{\"baz\", \"qux\"}}"` + "\n"
+		var b bytes.Buffer
+		testLogger(&b, nil).Info("login", "user", Slog(u))
+		if s := b.String(); s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("log valuer, lazy", func(t *testing.T) {
+		var b bytes.Buffer
+		countingEnumCalls = 0
+		testLogger(&b, nil).Debug("skipped", "value", Printer{EnumNames: true}.Slog(countingEnum(1)))
+		if countingEnumCalls != 0 || b.Len() != 0 {
+			t.Fatalf("expected: no rendering, got: %d calls, %q", countingEnumCalls, b.String())
+		}
+
+		testLogger(&b, nil).Info("logged", "value", Printer{EnumNames: true}.Slog(countingEnum(1)))
+		if countingEnumCalls != 1 {
+			t.Fatalf("expected: 1 call, got: %d", countingEnumCalls)
+		}
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		const expect = `level=INFO msg=login count=2 user="{Name: \"foo\", Password: /* redacted */, Groups: []{...}}"` +
+			` err=failed token="/* redacted */"` + "\n"
+
+		var b bytes.Buffer
+		testLogger(&b, &SlogOptions{Printer: Printer{MaxDepth: 1, Redact: []string{"Password", "token"}}}).Info(
+			"login",
+			"count", 2,
+			"user", &u,
+			"err", errors.New("failed"),
+			"token", "secret",
+		)
+
+		if s := b.String(); s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("handler, groups and attributes", func(t *testing.T) {
+		const expect = `level=INFO msg=request req.id=42 req.header="map{\"Accept\": []{\"*/*\"}}" ` +
+			`req.body.size=3 req.body.parts="[]{1, 2}"` + "\n"
+
+		var b bytes.Buffer
+		testLogger(&b, &SlogOptions{}).
+			WithGroup("req").
+			With("id", 42, "header", map[string][]string{"Accept": {"*/*"}}).
+			Info("request", slog.Group("body", "size", 3, "parts", []int{1, 2}))
+
+		if s := b.String(); s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("handler, wrapped", func(t *testing.T) {
+		var b bytes.Buffer
+		testLogger(&b, &SlogOptions{Wrap: true}).Info("wrapped", "value", []string{
+			strings.Repeat("foo", 10),
+			strings.Repeat("bar", 10),
+			strings.Repeat("baz", 10),
+		})
+
+		if s := b.String(); !strings.Contains(s, `value="[]{\n\t\"foofoo`) {
+			t.Fatalf("expected: wrapped value, got: %s", s)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RawStrings controls when the strings are printed as raw string literals, between backquotes.
+type RawStrings int
+
+const (
+
+	// DefaultRawStrings prints a string as a raw string literal only when the string needs to be wrapped,
+	// contains a newline, and doesn't contain a backquote.
+	DefaultRawStrings RawStrings = iota
+
+	// PreferRawStrings prints the strings as raw string literals whenever they can be represented that way,
+	// i.e. they are valid UTF-8, and don't contain a backquote, a carriage return or non-printable
+	// characters other than newlines and tabs.
+	PreferRawStrings
+
+	// NeverRawStrings always prints the strings as quoted strings, escaping the newlines. This guarantees that
+	// a string is always printed on a single line.
+	NeverRawStrings
+)
+
+func canBeRaw(s string, asciiOnly bool) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+
+	for _, c := range s {
+		switch {
+		case c == '`' || c == '\r':
+			return false
+		case c == '\n' || c == '\t':
+		case asciiOnly && c > unicode.MaxASCII:
+			return false
+		case !unicode.IsPrint(c):
+			return false
+		}
+	}
+
+	return true
+}
+
+// truncateString cuts s to at most max bytes, without splitting a multi-byte character, and returns the number
+// of the bytes that were cut.
+func truncateString(s string, max int) (string, int) {
+	if max <= 0 || len(s) <= max {
+		return s, 0
+	}
+
+	n := max
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n], len(s) - n
+}
+
+func (pr *Printer) quote(s string) string {
+	if pr.ASCIIStrings {
+		return strconv.QuoteToASCII(s)
+	}
+
+	return strconv.Quote(s)
+}
+
+// quotedString returns the node of a string literal, applying the string options of the printer. The string
+// itself is placed in a separate node, because the wrapping expects str to be the only part of its node.
+func (pr *Printer) quotedString(sv string) node {
+	full := sv
+	sv, cut := truncateString(sv, pr.MaxStringLen)
+
+	s := str{val: pr.quote(sv)}
+	switch pr.RawStrings {
+	case PreferRawStrings:
+		if canBeRaw(sv, pr.ASCIIStrings) {
+			s.val = fmt.Sprintf("`%s`", sv)
+			s.raw = s.val
+			s.useRaw = true
+		}
+	case NeverRawStrings:
+	default:
+		if !strings.Contains(sv, "`") && strings.Contains(sv, "\n") &&
+			(!pr.ASCIIStrings || canBeRaw(sv, true)) {
+			s.raw = fmt.Sprintf("`%s`", sv)
+		}
+	}
+
+	if cut == 0 && !pr.StringLength {
+		return nodeOf(s)
+	}
+
+	n := nodeOf(nodeOf(s))
+	if cut > 0 {
+		n.parts = append(n.parts, fmt.Sprintf("...(+%d bytes)", cut))
+	}
+
+	if pr.StringLength {
+		runes := utf8.RuneCountInString(full)
+		if runes == len(full) {
+			n.parts = append(n.parts, text(fmt.Sprintf(" /* len: %d */", len(full))))
+		} else {
+			n.parts = append(n.parts, text(fmt.Sprintf(" /* len: %d, runes: %d */", len(full), runes)))
+		}
+	}
+
+	return n
+}
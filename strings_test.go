@@ -0,0 +1,93 @@
+package notation
+
+import "testing"
+
+func TestStringOptions(t *testing.T) {
+	for _, test := range []struct {
+		title   string
+		printer Printer
+		value   interface{}
+		expect  string
+	}{{
+		title:   "truncated",
+		printer: Printer{MaxStringLen: 15},
+		value:   "The quick brown fox jumps over the lazy dog.",
+		expect:  `"The quick brown"...(+29 bytes)`,
+	}, {
+		title:   "truncated at character boundary",
+		printer: Printer{MaxStringLen: 2},
+		value:   "héllo",
+		expect:  `"h"...(+5 bytes)`,
+	}, {
+		title:   "shorter than the max",
+		printer: Printer{MaxStringLen: 15},
+		value:   "foo",
+		expect:  `"foo"`,
+	}, {
+		title:   "ascii only",
+		printer: Printer{ASCIIStrings: true},
+		value:   "héllo",
+		expect:  `"h\u00e9llo"`,
+	}, {
+		title:   "length",
+		printer: Printer{StringLength: true},
+		value:   []string{"hello", "héllo"},
+		expect:  `[]{"hello" /* len: 5 */, "héllo" /* len: 6, runes: 5 */}`,
+	}, {
+		title:   "length of the truncated string",
+		printer: Printer{MaxStringLen: 3, StringLength: true},
+		value:   "abcdef",
+		expect:  `"abc"...(+3 bytes) /* len: 6 */`,
+	}, {
+		title:   "prefer raw",
+		printer: Printer{RawStrings: PreferRawStrings},
+		value:   []string{"foo\nbar", `a"b`, "a`b", "a\x00b", "a\r\nb"},
+		expect:  "[]{`foo\nbar`, `a\"b`, \"a`b\", \"a\\x00b\", \"a\\r\\nb\"}",
+	}, {
+		title:   "prefer raw, ascii only",
+		printer: Printer{RawStrings: PreferRawStrings, ASCIIStrings: true},
+		value:   []string{"héllo", `a"b`},
+		expect:  "[]{\"h\\u00e9llo\", `a\"b`}",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := test.printer.Sprint(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+
+	const long = "The quick brown fox jumps over the lazy dog.\nThe quick brown fox jumps over the lazy dog."
+
+	t.Run("never raw", func(t *testing.T) {
+		const expect = `{
+	Foo: "The quick brown fox jumps over the lazy dog.\nThe quick brown fox jumps over the lazy dog.",
+}`
+
+		defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+		s := Printer{RawStrings: NeverRawStrings}.Sprintw(struct{ Foo string }{long})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("truncated, wrapped", func(t *testing.T) {
+		const expect = `{
+	Foo: "The quick brown fox "...(+69 bytes),
+}`
+
+		defer withEnv(t, "TABWIDTH=0", "LINEWIDTH=0", "LINEWIDTH1=0")()
+		s := Printer{MaxStringLen: 20}.Sprintw(struct{ Foo string }{long})
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("truncated, with types", func(t *testing.T) {
+		const expect = `string("abc"...(+3 bytes))`
+		s := Printer{MaxStringLen: 3}.Sprintv("abcdef")
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
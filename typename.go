@@ -0,0 +1,327 @@
+package notation
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// TypeNameMode controls how the names of the named types are printed.
+type TypeNameMode int
+
+const (
+
+	// ShortTypeNames prints the type names without the package, e.g. Options. This is the default.
+	ShortTypeNames TypeNameMode = iota
+
+	// PackageTypeNames prints the type names qualified with the package name, e.g. server.Options.
+	PackageTypeNames
+
+	// FullTypeNames prints the type names qualified with the full import path of the package, e.g.
+	// github.com/acme/app/server.Options.
+	FullTypeNames
+
+	// AutoTypeNames prints the type names without the package, except for those names that would be
+	// ambiguous within the same print call. These are qualified with the package name, or, when that's not
+	// enough, with the full import path.
+	AutoTypeNames
+)
+
+// typeIdent is the qualified identifier of a named type, without the type
+// arguments in case of generic types.
+type typeIdent struct {
+	path, pkg, name string
+}
+
+func (id typeIdent) key() string {
+	return id.path + "." + id.name
+}
+
+func (id typeIdent) format(mode TypeNameMode) string {
+	switch mode {
+	case PackageTypeNames:
+		return id.pkg + "." + id.name
+	case FullTypeNames:
+		return id.key()
+	default:
+		return id.name
+	}
+}
+
+func packageName(t reflect.Type) string {
+	return strings.TrimSuffix(t.String(), "."+t.Name())
+}
+
+// packageNameOfPath is used only for the type arguments of generic types,
+// where only the import path is available. It assumes that the package name
+// matches the last element of the path, ignoring the major version suffix.
+func packageNameOfPath(path string) string {
+	elements := strings.Split(path, "/")
+	last := elements[len(elements)-1]
+	if len(elements) > 1 && isMajorVersion(last) {
+		last = elements[len(elements)-2]
+	}
+
+	if i := strings.IndexByte(last, '.'); i > 0 {
+		last = last[:i]
+	}
+
+	return last
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+
+	for _, c := range s[1:] {
+		if !unicode.IsDigit(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func splitTypeArgs(name string) (string, string) {
+	i := strings.IndexByte(name, '[')
+	if i < 0 || name[len(name)-1] != ']' {
+		return name, ""
+	}
+
+	return name[:i], name[i+1 : len(name)-1]
+}
+
+func isTypeNameChar(c rune) bool {
+	return c == '_' || c == '.' || c == '/' || c == '-' || c == '~' ||
+		unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+func rewriteTypeArgIdent(word string, ident func(typeIdent) string) string {
+	if strings.HasPrefix(word, "...") {
+		return "..." + rewriteTypeArgIdent(word[3:], ident)
+	}
+
+	i := strings.LastIndexByte(word, '.')
+	if i <= 0 || i == len(word)-1 {
+		if word == "uint8" {
+			return "byte"
+		}
+
+		return word
+	}
+
+	path := word[:i]
+	return ident(typeIdent{path: path, pkg: packageNameOfPath(path), name: word[i+1:]})
+}
+
+// rewriteTypeArgs rebuilds the type arguments of generic types, as returned
+// by reflect, calling ident for every qualified identifier, including the
+// ones nested in further type arguments or type literals. It also applies
+// the same spacing that is used for the types printed by notation.
+func rewriteTypeArgs(args string, ident func(typeIdent) string) string {
+	var b strings.Builder
+	for len(args) > 0 {
+		n := strings.IndexFunc(args, func(c rune) bool { return !isTypeNameChar(c) })
+		if n < 0 {
+			n = len(args)
+		}
+
+		if n > 0 {
+			word := args[:n]
+			args = args[n:]
+			b.WriteString(rewriteTypeArgIdent(word, ident))
+			if (word == "struct" || word == "interface") && strings.HasPrefix(args, " {") {
+				b.WriteString("{")
+				args = strings.TrimPrefix(args[2:], " ")
+			}
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(args, "·"):
+			// the types declared inside functions get a numeric suffix:
+			args = strings.TrimLeftFunc(args[len("·"):], unicode.IsDigit)
+			continue
+		case args[0] == ',' && !strings.HasPrefix(args, ", "):
+			b.WriteString(", ")
+		case strings.HasPrefix(args, " }"):
+			b.WriteString("}")
+			args = args[1:]
+		default:
+			b.WriteByte(args[0])
+		}
+
+		args = args[1:]
+	}
+
+	return b.String()
+}
+
+func (pr *Printer) formatTypeIdent(id typeIdent) string {
+	mode := pr.TypeNames
+	if mode == AutoTypeNames {
+		mode = pr.autoTypeNames[id.key()]
+	}
+
+	return id.format(mode)
+}
+
+func (pr *Printer) typeName(t reflect.Type) string {
+	name := t.Name()
+	if t.PkgPath() == "" {
+		if name == "uint8" {
+			name = "byte"
+		}
+
+		return name
+	}
+
+	name, args := splitTypeArgs(name)
+	name = pr.formatTypeIdent(typeIdent{path: t.PkgPath(), pkg: packageName(t), name: name})
+	if args == "" {
+		return name
+	}
+
+	return name + "[" + rewriteTypeArgs(args, pr.formatTypeIdent) + "]"
+}
+
+func collectType(types map[reflect.Type]bool, t reflect.Type) {
+	if types[t] {
+		return
+	}
+
+	types[t] = true
+	if t.Name() != "" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
+		collectType(types, t.Elem())
+	case reflect.Map:
+		collectType(types, t.Key())
+		collectType(types, t.Elem())
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			collectType(types, t.In(i))
+		}
+
+		for i := 0; i < t.NumOut(); i++ {
+			collectType(types, t.Out(i))
+		}
+	case reflect.Interface:
+		for i := 0; i < t.NumMethod(); i++ {
+			collectType(types, t.Method(i).Type)
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			collectType(types, t.Field(i).Type)
+		}
+	}
+}
+
+func collectTypes(types map[reflect.Type]bool, visited map[uintptr]bool, r reflect.Value) {
+	if !r.IsValid() {
+		return
+	}
+
+	collectType(types, r.Type())
+	switch r.Kind() {
+	case reflect.Map, reflect.Ptr, reflect.Slice:
+		if r.IsNil() || visited[r.Pointer()] {
+			return
+		}
+
+		visited[r.Pointer()] = true
+	}
+
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < r.Len(); i++ {
+			collectTypes(types, visited, r.Index(i))
+		}
+	case reflect.Interface, reflect.Ptr:
+		collectTypes(types, visited, r.Elem())
+	case reflect.Map:
+		for _, key := range r.MapKeys() {
+			collectTypes(types, visited, key)
+			collectTypes(types, visited, r.MapIndex(key))
+		}
+	case reflect.Struct:
+		for i := 0; i < r.NumField(); i++ {
+			collectTypes(types, visited, r.Field(i))
+		}
+	}
+}
+
+// autoTypeNames finds the type names that collide, and selects the shortest
+// qualification that makes them unique.
+func autoTypeNames(v []interface{}) map[string]TypeNameMode {
+	types := make(map[reflect.Type]bool)
+	visited := make(map[uintptr]bool)
+	for _, vi := range v {
+		collectTypes(types, visited, reflect.ValueOf(vi))
+	}
+
+	idents := make(map[string]typeIdent)
+	collectIdent := func(id typeIdent) string {
+		if _, ok := idents[id.key()]; !ok {
+			idents[id.key()] = id
+		}
+
+		return ""
+	}
+
+	for t := range types {
+		if t.Name() == "" || t.PkgPath() == "" {
+			continue
+		}
+
+		name, args := splitTypeArgs(t.Name())
+		id := typeIdent{path: t.PkgPath(), pkg: packageName(t), name: name}
+		idents[id.key()] = id
+		rewriteTypeArgs(args, collectIdent)
+	}
+
+	byName := make(map[string][]typeIdent)
+	for _, id := range idents {
+		byName[id.name] = append(byName[id.name], id)
+	}
+
+	unique := func(mode TypeNameMode, ids []typeIdent) bool {
+		names := make(map[string]bool)
+		for _, id := range ids {
+			name := id.format(mode)
+			if names[name] {
+				return false
+			}
+
+			names[name] = true
+		}
+
+		return true
+	}
+
+	modes := make(map[string]TypeNameMode)
+	for _, ids := range byName {
+		if len(ids) < 2 {
+			continue
+		}
+
+		for _, mode := range []TypeNameMode{PackageTypeNames, FullTypeNames} {
+			if !unique(mode, ids) {
+				continue
+			}
+
+			for _, id := range ids {
+				modes[id.key()] = mode
+			}
+
+			break
+		}
+	}
+
+	return modes
+}
@@ -0,0 +1,162 @@
+package notation
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTypeNames(t *testing.T) {
+	type readers struct {
+		bytes   *bytes.Reader
+		strings *strings.Reader
+	}
+
+	type templates struct {
+		text *template.Template
+		html *htmltemplate.Template
+	}
+
+	for _, test := range []struct {
+		title  string
+		mode   TypeNameMode
+		value  interface{}
+		expect string
+	}{{
+		title:  "short",
+		value:  myInt(42),
+		expect: "myInt(42)",
+	}, {
+		title:  "package",
+		mode:   PackageTypeNames,
+		value:  myInt(42),
+		expect: "notation.myInt(42)",
+	}, {
+		title:  "full",
+		mode:   FullTypeNames,
+		value:  myInt(42),
+		expect: "github.com/aryszka/notation.myInt(42)",
+	}, {
+		title:  "builtin types are not qualified",
+		mode:   FullTypeNames,
+		value:  []byte{42},
+		expect: "[]byte{2a}",
+	}, {
+		title:  "auto, no collision",
+		mode:   AutoTypeNames,
+		value:  struct{ r *bytes.Reader }{},
+		expect: "struct{r *Reader}{r: (*Reader)(nil)}",
+	}, {
+		title:  "auto, collision",
+		mode:   AutoTypeNames,
+		value:  readers{},
+		expect: "readers{bytes: (*bytes.Reader)(nil), strings: (*strings.Reader)(nil)}",
+	}, {
+		title:  "auto, collision in type literal",
+		mode:   AutoTypeNames,
+		value:  map[*bytes.Reader]*strings.Reader{},
+		expect: "map[*bytes.Reader]*strings.Reader{}",
+	}, {
+		title:  "auto, package name collision",
+		mode:   AutoTypeNames,
+		value:  struct{ t templates }{},
+		expect: "struct{t templates}{t: templates{text: (*text/template.Template)(nil), html: (*html/template.Template)(nil)}}",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := Printer{TypeNames: test.mode}.Sprintv(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+
+	t.Run("auto, collision across values", func(t *testing.T) {
+		const expect = "(*bytes.Reader)(nil) (*strings.Reader)(nil)"
+		s := Printer{TypeNames: AutoTypeNames}.Sprintv((*bytes.Reader)(nil), (*strings.Reader)(nil))
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}
+
+type (
+	genericList[T any]               struct{ items []T }
+	genericPair[K comparable, V any] struct{}
+)
+
+func TestGenericTypeNames(t *testing.T) {
+	type local struct{}
+
+	for _, test := range []struct {
+		title  string
+		mode   TypeNameMode
+		value  interface{}
+		expect string
+	}{{
+		title:  "builtin argument",
+		value:  genericList[int]{},
+		expect: "genericList[int]{items: nil}",
+	}, {
+		title:  "qualified argument",
+		value:  genericList[*bytes.Reader]{},
+		expect: "genericList[*Reader]{items: nil}",
+	}, {
+		title:  "qualified argument, package names",
+		mode:   PackageTypeNames,
+		value:  genericList[*bytes.Reader]{},
+		expect: "notation.genericList[*bytes.Reader]{items: nil}",
+	}, {
+		title:  "qualified argument, full names",
+		mode:   FullTypeNames,
+		value:  genericList[*bytes.Reader]{},
+		expect: "github.com/aryszka/notation.genericList[*bytes.Reader]{items: nil}",
+	}, {
+		title:  "multiple arguments",
+		value:  genericPair[string, []byte]{},
+		expect: "genericPair[string, []byte]{}",
+	}, {
+		title:  "nested arguments",
+		mode:   PackageTypeNames,
+		value:  genericList[genericPair[*template.Template, map[string]strings.Reader]]{},
+		expect: "notation.genericList[notation.genericPair[*template.Template, map[string]strings.Reader]]{items: nil}",
+	}, {
+		title:  "function argument",
+		value:  genericList[func(int, ...*bytes.Reader) (string, error)]{},
+		expect: "genericList[func(int, ...*Reader) (string, error)]{items: nil}",
+	}, {
+		title:  "struct argument",
+		value:  genericList[struct{ foo *bytes.Reader }]{},
+		expect: "genericList[struct{foo *Reader}]{items: nil}",
+	}, {
+		title:  "local type argument",
+		value:  genericList[local]{},
+		expect: "genericList[local]{items: nil}",
+	}, {
+		title:  "local type argument, package names",
+		mode:   PackageTypeNames,
+		value:  genericList[local]{},
+		expect: "notation.genericList[notation.local]{items: nil}",
+	}, {
+		title:  "auto, collision in arguments",
+		mode:   AutoTypeNames,
+		value:  genericPair[*bytes.Reader, *strings.Reader]{},
+		expect: "genericPair[*bytes.Reader, *strings.Reader]{}",
+	}, {
+		title: "auto, collision with argument",
+		mode:  AutoTypeNames,
+		value: struct {
+			list   genericList[*bytes.Reader]
+			reader *strings.Reader
+		}{},
+		expect: "struct{list genericList[*bytes.Reader]; reader *strings.Reader}{list: {items: nil}, reader: nil}",
+	}} {
+		t.Run(test.title, func(t *testing.T) {
+			s := Printer{TypeNames: test.mode}.Sprintt(test.value)
+			if s != test.expect {
+				t.Fatalf("expected: %s, got: %s", test.expect, s)
+			}
+		})
+	}
+}
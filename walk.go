@@ -0,0 +1,143 @@
+package notation
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+)
+
+// WalkAction tells Walk how to continue after visiting a value.
+type WalkAction int
+
+const (
+
+	// Continue visits the nested values of the current value.
+	Continue WalkAction = iota
+
+	// Skip skips the nested values of the current value, and continues with its siblings.
+	Skip
+
+	// Stop stops the walk.
+	Stop
+)
+
+type walker struct {
+	visit      func(Path, reflect.Value) WalkAction
+	inProgress map[refKey]bool
+
+	// when set, the struct fields and the map entries are visited only when they would be printed, see
+	// selectValues:
+	printer *Printer
+}
+
+// Walk visits a value and its nested values, following the same rules as the print functions: the
+// interfaces are visited as their underlying values, the pointers are dereferenced, the map entries are
+// visited in the order of their printed keys, and the struct fields in the order of their declaration,
+// including the unexported ones. The pointers, maps and slices that are referenced from within
+// themselves are visited, but their nested values are not visited again.
+//
+// The values are passed in as reflect returns them, and so, e.g., the values of the unexported fields can be
+// read with the kind specific methods, like Int(), but they don't support Interface(), and they cannot be
+// set.
+func Walk(v interface{}, visit func(path Path, v reflect.Value) WalkAction) {
+	w := &walker{visit: visit, inProgress: make(map[refKey]bool)}
+	w.walk(nil, reflect.ValueOf(v))
+}
+
+func walkKey(r reflect.Value) (refKey, bool) {
+	switch r.Kind() {
+	case reflect.Map, reflect.Ptr:
+		return refKey{ptr: r.Pointer(), typ: r.Type()}, true
+	case reflect.Slice:
+		return refKey{ptr: r.Pointer(), typ: r.Type(), len: r.Len()}, true
+	default:
+		return refKey{}, false
+	}
+}
+
+type printedKey struct {
+	text string
+	key  reflect.Value
+}
+
+// sortedMapKeys returns the keys of a map in the same order as they are printed. Different keys can be
+// printed the same way, e.g. 1 and int64(1) in a map[interface{}]int, and so the printed form is used only
+// for sorting.
+func sortedMapKeys(r reflect.Value) []reflect.Value {
+	var pkeys []printedKey
+	for _, key := range r.MapKeys() {
+		var b bytes.Buffer
+		fprint(&writer{w: &b}, 0, reflectValue(skipTypes|_pointerValues, &Printer{}, newPending(), key))
+		pkeys = append(pkeys, printedKey{text: b.String(), key: key})
+	}
+
+	sort.SliceStable(pkeys, func(i, j int) bool { return pkeys[i].text < pkeys[j].text })
+	keys := make([]reflect.Value, len(pkeys))
+	for i, pk := range pkeys {
+		keys[i] = pk.key
+	}
+
+	return keys
+}
+
+// walk returns false when the walk needs to be stopped.
+func (w *walker) walk(path Path, r reflect.Value) bool {
+	if !r.IsValid() {
+		return true
+	}
+
+	if r.Kind() == reflect.Interface && !r.IsNil() {
+		return w.walk(path, r.Elem())
+	}
+
+	switch w.visit(path, r) {
+	case Skip:
+		return true
+	case Stop:
+		return false
+	}
+
+	key, isRef := walkKey(r)
+	if isRef {
+		if r.IsNil() || w.inProgress[key] {
+			return true
+		}
+
+		w.inProgress[key] = true
+		defer delete(w.inProgress, key)
+	}
+
+	switch r.Kind() {
+	case reflect.Ptr:
+		return w.walk(path.append(PathStep{Kind: DerefStep}), r.Elem())
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < r.Len(); i++ {
+			if !w.walk(path.append(PathStep{Kind: IndexStep, Index: i}), r.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(r) {
+			if w.printer != nil && w.printer.OmitZeroMapEntries && r.MapIndex(key).IsZero() {
+				continue
+			}
+
+			if !w.walk(path.append(PathStep{Kind: KeyStep, Key: key}), r.MapIndex(key)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		if w.printer != nil {
+			return w.walkPrintedFields(path, r)
+		}
+
+		for i := 0; i < r.NumField(); i++ {
+			step := PathStep{Kind: FieldStep, Name: r.Type().Field(i).Name}
+			if !w.walk(path.append(step), r.Field(i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,161 @@
+package notation
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	type item struct {
+		Name  string
+		count int
+	}
+
+	type node struct {
+		Items  []item
+		Labels map[string]interface{}
+		Next   *node
+	}
+
+	walkPaths := func(v interface{}, action func(Path, reflect.Value) WalkAction) string {
+		var paths []string
+		Walk(v, func(p Path, r reflect.Value) WalkAction {
+			paths = append(paths, p.String()+"="+sprintValue(r))
+			return action(p, r)
+		})
+
+		return strings.Join(paths, " ")
+	}
+
+	cont := func(Path, reflect.Value) WalkAction { return Continue }
+
+	t.Run("nested values", func(t *testing.T) {
+		v := node{
+			Items:  []item{{Name: "foo", count: 1}},
+			Labels: map[string]interface{}{"b": 2, "a": []int{1}},
+		}
+
+		s := walkPaths(v, cont)
+		expect := `={Items: []{{Name: "foo", count: 1}}, Labels: map{"a": []{1}, "b": 2}, Next: nil} ` +
+			`.Items=[]{{Name: "foo", count: 1}} .Items[0]={Name: "foo", count: 1} .Items[0].Name="foo" ` +
+			`.Items[0].count=1 .Labels=map{"a": []{1}, "b": 2} .Labels["a"]=[]{1} .Labels["a"][0]=1 ` +
+			`.Labels["b"]=2 .Next=nil`
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("unexported values are read-only", func(t *testing.T) {
+		var (
+			count    int64
+			readOnly bool
+		)
+
+		Walk(&item{count: 42}, func(p Path, r reflect.Value) WalkAction {
+			if p.String() == ".count" {
+				count = r.Int()
+				readOnly = !r.CanSet() && !r.CanInterface()
+			}
+
+			return Continue
+		})
+
+		if count != 42 || !readOnly {
+			t.Fatalf("expected: read-only 42, got: %d, read-only: %t", count, readOnly)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		v := node{Items: []item{{Name: "foo"}}}
+		s := walkPaths(v, func(p Path, _ reflect.Value) WalkAction {
+			if p.String() == ".Items" {
+				return Skip
+			}
+
+			return Continue
+		})
+
+		expect := `={Items: []{{Name: "foo", count: 0}}, Labels: nil, Next: nil} ` +
+			`.Items=[]{{Name: "foo", count: 0}} .Labels=nil .Next=nil`
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		v := []int{1, 2, 3}
+		s := walkPaths(v, func(p Path, _ reflect.Value) WalkAction {
+			if p.String() == "[1]" {
+				return Stop
+			}
+
+			return Continue
+		})
+
+		expect := "=[]{1, 2, 3} [0]=1 [1]=2"
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		n := &node{}
+		n.Next = n
+		var paths []string
+		Walk(n, func(p Path, _ reflect.Value) WalkAction {
+			paths = append(paths, p.String())
+			return Continue
+		})
+
+		// the root pointer and the struct that it references have the same path:
+		s := strings.Join(paths, ",")
+		expect := ",,.Items,.Labels,.Next"
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+
+	t.Run("keys printed the same way", func(t *testing.T) {
+		var values []int
+		Walk(map[interface{}]int{1: 10, int64(1): 20}, func(p Path, r reflect.Value) WalkAction {
+			if len(p) > 0 {
+				values = append(values, int(r.Int()))
+			}
+
+			return Continue
+		})
+
+		sort.Ints(values)
+		if len(values) != 2 || values[0] != 10 || values[1] != 20 {
+			t.Fatalf("expected: %v, got: %v", []int{10, 20}, values)
+		}
+	})
+
+	t.Run("path steps", func(t *testing.T) {
+		var path Path
+		Walk(map[string]*item{"foo": {}}, func(p Path, _ reflect.Value) WalkAction {
+			if len(p) > 0 && p[len(p)-1].Kind == FieldStep && p[len(p)-1].Name == "Name" {
+				path = p
+			}
+
+			return Continue
+		})
+
+		var kinds []PathStepKind
+		for _, step := range path {
+			kinds = append(kinds, step.Kind)
+		}
+
+		if !reflect.DeepEqual(kinds, []PathStepKind{KeyStep, DerefStep, FieldStep}) {
+			t.Fatalf("expected: %v, got: %v", []PathStepKind{KeyStep, DerefStep, FieldStep}, kinds)
+		}
+
+		s := path.String()
+		expect := `["foo"].Name`
+		if s != expect {
+			t.Fatalf("expected: %s, got: %s", expect, s)
+		}
+	})
+}